@@ -0,0 +1,98 @@
+package bimap
+
+import "sync"
+
+// AliasMap is a variant of BiMap that allows many aliases to be bound to one value,
+// the first alias bound to a value is its canonical alias
+type AliasMap[T, U comparable] struct {
+	rwLock  sync.RWMutex
+	aliases map[T]U
+	values  map[U][]T
+}
+
+// NewAliasMap returns an AliasMap object
+func NewAliasMap[T, U comparable]() *AliasMap[T, U] {
+	return &AliasMap[T, U]{
+		aliases: make(map[T]U),
+		values:  make(map[U][]T),
+	}
+}
+
+// SetAlias binds the alias to the value, an alias already bound to another value will be rebound
+func (m *AliasMap[T, U]) SetAlias(alias T, val U) {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	if old, ok := m.aliases[alias]; ok {
+		if old == val {
+			return
+		}
+		m.unbind(alias, old)
+	}
+	m.aliases[alias] = val
+	m.values[val] = append(m.values[val], alias)
+}
+
+// GetByAlias returns the value and its existence by the given alias
+func (m *AliasMap[T, U]) GetByAlias(alias T) (U, bool) {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	v, ok := m.aliases[alias]
+	return v, ok
+}
+
+// GetCanonical returns the canonical alias and its existence by the given value
+func (m *AliasMap[T, U]) GetCanonical(val U) (T, bool) {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	aliases, ok := m.values[val]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return aliases[0], true
+}
+
+// GetAliases returns all aliases bound to the given value in binding order
+func (m *AliasMap[T, U]) GetAliases(val U) []T {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	aliases := m.values[val]
+	res := make([]T, len(aliases))
+	copy(res, aliases)
+	return res
+}
+
+// DeleteAlias deletes the given alias
+func (m *AliasMap[T, U]) DeleteAlias(alias T) {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	v, ok := m.aliases[alias]
+	if !ok {
+		return
+	}
+	delete(m.aliases, alias)
+	m.unbind(alias, v)
+}
+
+// Len returns the number of aliases in the AliasMap object
+func (m *AliasMap[_, _]) Len() int {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	return len(m.aliases)
+}
+
+// unbind removes the alias from the alias list of the value, the caller must hold the write lock
+func (m *AliasMap[T, U]) unbind(alias T, val U) {
+	aliases := m.values[val]
+	for i, a := range aliases {
+		if a == alias {
+			aliases = append(aliases[:i], aliases[i+1:]...)
+			break
+		}
+	}
+	if len(aliases) == 0 {
+		delete(m.values, val)
+		return
+	}
+	m.values[val] = aliases
+}
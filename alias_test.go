@@ -0,0 +1,33 @@
+package bimap
+
+import "testing"
+
+func TestAliasMap(t *testing.T) {
+	m := NewAliasMap[string, int]()
+	m.SetAlias("nyc", 1)
+	m.SetAlias("new york", 1)
+	m.SetAlias("big apple", 1)
+	m.SetAlias("la", 2)
+	for _, alias := range []string{"nyc", "new york", "big apple"} {
+		if val, _ := m.GetByAlias(alias); val != 1 {
+			t.Errorf("Values not equal, want: %d, got: %d", 1, val)
+		}
+	}
+	if aliases := m.GetAliases(1); len(aliases) != 3 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 3, len(aliases))
+	}
+	if alias, _ := m.GetCanonical(1); alias != "nyc" {
+		t.Errorf("Values not equal, want: %s, got: %s", "nyc", alias)
+	}
+	m.DeleteAlias("nyc")
+	if alias, _ := m.GetCanonical(1); alias != "new york" {
+		t.Errorf("Values not equal, want: %s, got: %s", "new york", alias)
+	}
+	m.SetAlias("la", 1)
+	if _, ok := m.GetCanonical(2); ok {
+		t.Error("Value should have no aliases")
+	}
+	if l := m.Len(); l != 3 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 3, l)
+	}
+}
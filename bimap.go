@@ -1,18 +1,107 @@
 package bimap
 
 import (
+	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"hash/fnv"
+	"hash/maphash"
+	"iter"
+	"reflect"
+	"sort"
 	"strings"
 	"sync"
+	"unsafe"
 )
 
-var ErrKeyValExists = errors.New("key or value exists")
+var (
+	ErrKeyValExists   = errors.New("key or value exists")
+	ErrLengthMismatch = errors.New("length mismatch")
+	ErrKeyNotExists   = errors.New("key not exists")
+	ErrValueMismatch  = errors.New("value mismatch")
+	ErrZeroValue      = errors.New("zero value")
+	ErrInvariant      = errors.New("front map and back map diverged")
+	ErrGrowOnly       = errors.New("map is grow-only")
+)
 
 type BiMap[T, U comparable] struct {
-	rwLock sync.RWMutex
-	front  map[T]U
-	back   map[U]T
+	rwLock  sync.RWMutex
+	front   map[T]U
+	back    map[U]T
+	last    T
+	hasLast bool
+	subs    map[int]chan Event[T, U]
+	nextSub int
+	// observers are called with every mutation, panics are recovered and passed to panicHandler
+	observers    []func(Event[T, U])
+	panicHandler func(any)
+	metrics      *metrics
+	dflt         *defaultValue[U]
+	// validators are run on pairs set by methods that return an error
+	validators []func(T, U) error
+	backRead   func(T) T
+	initOnce   sync.Once
+	initErr    error
+	intern     func(T) T
+	loader     func(T) (U, bool)
+	loadMu     sync.Mutex
+	loads      map[T]*loadCall[U]
+	// changes are tracked per key after the first Checkpoint call
+	changes map[T]change[U]
+	version uint64
+	// frozen is set while Freeze is in effect or a swap is prepared, which are tracked by held and swapping
+	frozen   bool
+	held     bool
+	swapping bool
+	// keys are never removed when growOnly is set
+	growOnly bool
+	// insertion order is tracked after WithInsertionOrder, deleted keys leave tombstones in order
+	// and the entries before orderHead are all tombstones
+	order     []T
+	orderIdx  map[T]int
+	orderHead int
+}
+
+// Pair is a pair of associated front and back keys
+type Pair[T, U comparable] struct {
+	Front T
+	Back  U
+}
+
+// Entry is a key-value pair in front map
+type Entry[T, U comparable] struct {
+	Key   T
+	Value U
+}
+
+// Lookup is the result of looking up a single key
+type Lookup[U comparable] struct {
+	Val U
+	OK  bool
+}
+
+// ConflictKind describes which side of a pair collides with existing pairs
+type ConflictKind int
+
+const (
+	// ConflictKey means the key already exists in front map
+	ConflictKey ConflictKind = 1 << iota
+	// ConflictValue means the value already exists in back map
+	ConflictValue
+	// ConflictBoth means both the key and the value already exist
+	ConflictBoth = ConflictKey | ConflictValue
+)
+
+// Conflict describes a proposed pair that cannot be set in front map
+type Conflict[T, U comparable] struct {
+	Key   T
+	Value U
+	Kind  ConflictKind
+	// ExistingValue is the value currently bound to Key, it is only set if Kind has ConflictKey
+	ExistingValue U
+	// ExistingKey is the key currently bound to Value, it is only set if Kind has ConflictValue
+	ExistingKey T
 }
 
 type option[T, U comparable] interface {
@@ -21,16 +110,130 @@ type option[T, U comparable] interface {
 
 type initialOption[T, U comparable] map[T]U
 
+// apply copies the pairs into the BiMap object, so the same option can be reused across New calls
 func (io initialOption[T, U]) apply(m *BiMap[T, U]) {
 	for k, v := range map[T]U(io) {
 		m.front[k] = v
 		m.back[v] = k
+		m.insertOrder(k)
 	}
 }
 
-// WithInitialMap returns an initialOption object that implements the option interface
+// WithInitialMap returns an initialOption object that implements the option interface,
+// the given map is copied so later changes to it do not affect the option or any BiMap object created with it
 func WithInitialMap[T, U comparable](m map[T]U) option[T, U] {
-	return initialOption[T, U](m)
+	io := make(initialOption[T, U], len(m))
+	for k, v := range m {
+		io[k] = v
+	}
+	return io
+}
+
+// fingerprintSeed is shared by all BiMap objects so that fingerprints are comparable within a process
+var fingerprintSeed = maphash.MakeSeed()
+
+// rlockPair read-locks both mutexes in a consistent order and returns a function that releases them
+func rlockPair(a, b *sync.RWMutex) func() {
+	if a == b {
+		a.RLock()
+		return a.RUnlock
+	}
+	if uintptr(unsafe.Pointer(a)) > uintptr(unsafe.Pointer(b)) {
+		a, b = b, a
+	}
+	a.RLock()
+	b.RLock()
+	return func() {
+		b.RUnlock()
+		a.RUnlock()
+	}
+}
+
+type defaultValue[U comparable] struct {
+	val   U
+	found bool
+}
+
+type defaultOption[T, U comparable] defaultValue[U]
+
+func (do defaultOption[T, U]) apply(m *BiMap[T, U]) {
+	m.dflt = &defaultValue[U]{val: do.val, found: do.found}
+}
+
+// WithDefaultValue returns a defaultOption object that implements the option interface,
+// GetFront returns def for absent keys, with its existence reported as found
+func WithDefaultValue[T, U comparable](def U, found bool) option[T, U] {
+	return defaultOption[T, U]{val: def, found: found}
+}
+
+// lockPair locks both mutexes in a consistent order and returns a function that releases them
+func lockPair(a, b *sync.RWMutex) func() {
+	if a == b {
+		a.Lock()
+		return a.Unlock
+	}
+	if uintptr(unsafe.Pointer(a)) > uintptr(unsafe.Pointer(b)) {
+		a, b = b, a
+	}
+	a.Lock()
+	b.Lock()
+	return func() {
+		b.Unlock()
+		a.Unlock()
+	}
+}
+
+type validatorOption[T, U comparable] func(T, U) error
+
+func (vo validatorOption[T, U]) apply(m *BiMap[T, U]) {
+	m.validators = append(m.validators, vo)
+}
+
+// WithValidator returns a validatorOption object that implements the option interface, every pair set by a method
+// is checked by fn and methods that return an error return the error of fn, the others leave the map unchanged.
+// Pairs given to WithInitialMap and edits made by MutateRaw are not checked, the option can be given multiple times
+func WithValidator[T, U comparable](fn func(T, U) error) option[T, U] {
+	return validatorOption[T, U](fn)
+}
+
+type backReadOption[T, U comparable] func(T) T
+
+func (bo backReadOption[T, U]) apply(m *BiMap[T, U]) {
+	m.backRead = bo
+}
+
+// WithBackReadTransform returns a backReadOption object that implements the option interface,
+// GetBack applies fn to the values it returns, the stored pairs and other methods are not affected
+func WithBackReadTransform[T, U comparable](fn func(T) T) option[T, U] {
+	return backReadOption[T, U](fn)
+}
+
+type growOnlyOption[T, U comparable] struct{}
+
+func (growOnlyOption[T, U]) apply(m *BiMap[T, U]) {
+	m.growOnly = true
+}
+
+// WithGrowOnly returns a growOnlyOption object that implements the option interface, keys are never removed once set.
+// Deleting methods without an error such as DeleteFront, DeleteBack and Clear become no-ops that report nothing was
+// deleted, and methods that return an error return ErrGrowOnly without modification if they would remove a key.
+// A key can still be given a new value, but no key is displaced to make room for it
+func WithGrowOnly[T, U comparable]() option[T, U] {
+	return growOnlyOption[T, U]{}
+}
+
+// checkGrowOnly returns ErrGrowOnly if the BiMap object is grow-only and replacing its pairs with pairs would remove a key,
+// the caller must hold the lock
+func (m *BiMap[T, U]) checkGrowOnly(pairs map[T]U) error {
+	if !m.growOnly {
+		return nil
+	}
+	for k := range m.front {
+		if _, ok := pairs[k]; !ok {
+			return ErrGrowOnly
+		}
+	}
+	return nil
 }
 
 // New returns a BiMap object
@@ -45,35 +248,256 @@ func New[T, U comparable](options ...option[T, U]) *BiMap[T, U] {
 	return m
 }
 
-// GetFront returns the value and its existence by the given key in front map
+// set stores the pair in both maps, the caller must hold the write lock and ensure neither side exists
+func (m *BiMap[T, U]) set(f T, b U) {
+	f = m.store(f, b)
+	m.onSet(f, b)
+}
+
+// remove deletes the pair from both maps, the caller must hold the write lock
+func (m *BiMap[T, U]) remove(f T, b U) {
+	m.unstore(f, b)
+	m.onRemove(f, b)
+}
+
+// store writes the pair to both maps only and returns the stored key, the caller must hold the write lock
+func (m *BiMap[T, U]) store(f T, b U) T {
+	if m.intern != nil {
+		f = m.intern(f)
+	}
+	m.front[f] = b
+	m.back[b] = f
+	return f
+}
+
+// unstore deletes the pair from both maps only, the caller must hold the write lock
+func (m *BiMap[T, U]) unstore(f T, b U) {
+	delete(m.front, f)
+	delete(m.back, b)
+}
+
+// onSet updates the state kept besides the maps and notifies subscribers after the pair is stored
+func (m *BiMap[T, U]) onSet(f T, b U) {
+	m.last, m.hasLast = f, true
+	m.metrics.set()
+	m.track(f, b, false)
+	m.insertOrder(f)
+	m.notify(Event[T, U]{Kind: EventSet, Front: f, Back: b})
+}
+
+// onRemove updates the state kept besides the maps and notifies subscribers after the pair is unstored
+func (m *BiMap[T, U]) onRemove(f T, b U) {
+	m.metrics.delete()
+	m.track(f, b, true)
+	m.deleteOrder(f)
+	if m.hasLast && m.last == f {
+		var zero T
+		m.last, m.hasLast = zero, false
+	}
+	m.notify(Event[T, U]{Kind: EventDelete, Front: f, Back: b})
+}
+
+// IsInjective reports whether no two keys of m share a value, it also returns a repeated value if not
+func IsInjective[T, U comparable](m map[T]U) (bool, U) {
+	seen := make(map[U]struct{}, len(m))
+	for _, v := range m {
+		if _, ok := seen[v]; ok {
+			return false, v
+		}
+		seen[v] = struct{}{}
+	}
+	var zero U
+	return true, zero
+}
+
+// NewFromSlices returns a BiMap object that pairs keys and vals by index, it will return an error if their lengths differ or if either key or value repeats
+func NewFromSlices[T, U comparable](keys []T, vals []U) (*BiMap[T, U], error) {
+	if len(keys) != len(vals) {
+		return nil, ErrLengthMismatch
+	}
+	m := New[T, U]()
+	for i, k := range keys {
+		if err := m.SetFront(k, vals[i]); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// replaceChecked replaces all pairs with the given pairs unless the BiMap object is frozen, it is grow-only and a key
+// would be removed, or a validator fails, the caller must hold the write lock and ensure pairs is injective
+func (m *BiMap[T, U]) replaceChecked(pairs map[T]U) error {
+	if m.frozen {
+		return ErrFrozen
+	}
+	if err := m.checkGrowOnly(pairs); err != nil {
+		return err
+	}
+	for k, v := range pairs {
+		if err := m.validate(k, v); err != nil {
+			return err
+		}
+	}
+	m.replace(pairs)
+	return nil
+}
+
+// replace removes all pairs and sets the given pairs, the caller must hold the write lock and ensure pairs is injective
+func (m *BiMap[T, U]) replace(pairs map[T]U) {
+	for f, b := range m.front {
+		m.remove(f, b)
+	}
+	for f, b := range pairs {
+		m.set(f, b)
+	}
+}
+
+// Index returns a BiMap object that maps keyfn to valfn of each item, it will return an error if either key or value repeats
+func Index[E any, T, U comparable](items []E, keyfn func(E) T, valfn func(E) U) (*BiMap[T, U], error) {
+	m := New[T, U]()
+	for _, item := range items {
+		if err := m.SetFront(keyfn(item), valfn(item)); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// GetFront returns the value and its existence by the given key in front map,
+// if the BiMap object is created WithLoader, absent keys are loaded first,
+// if the BiMap object is created WithDefaultValue, the default value is returned for absent keys
 func (m *BiMap[T, U]) GetFront(key T) (U, bool) {
 	m.rwLock.RLock()
-	defer m.rwLock.RUnlock()
+	m.metrics.get()
 	v, ok := m.front[key]
+	m.rwLock.RUnlock()
+	if !ok && m.loader != nil {
+		v, ok = m.load(key)
+	}
+	if !ok && m.dflt != nil {
+		return m.dflt.val, m.dflt.found
+	}
 	return v, ok
 }
 
-// GetBack returns the value and its existence by the given key in back map
+// GetBack returns the value and its existence by the given key in back map,
+// if the BiMap object is created WithBackReadTransform, the transform is applied to the value
 func (m *BiMap[T, U]) GetBack(key U) (T, bool) {
 	m.rwLock.RLock()
 	defer m.rwLock.RUnlock()
+	m.metrics.get()
 	v, ok := m.back[key]
+	if ok && m.backRead != nil {
+		v = m.backRead(v)
+	}
 	return v, ok
 }
 
+// GetFrontOrZero returns the value by the given key in front map, or the zero value if the key does not exist
+func (m *BiMap[T, U]) GetFrontOrZero(key T) U {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	m.metrics.get()
+	return m.front[key]
+}
+
+// GetBackOrZero returns the value by the given key in back map, or the zero value if the key does not exist
+func (m *BiMap[T, U]) GetBackOrZero(key U) T {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	m.metrics.get()
+	return m.back[key]
+}
+
+// Peek returns the value and its existence by the given key in front map together with the length of the BiMap object
+func (m *BiMap[T, U]) Peek(key T) (val U, found bool, size int) {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	val, found = m.front[key]
+	return val, found, len(m.front)
+}
+
+// GetFrontOrdered returns the lookup results of the given keys in front map, aligned with the order of keys
+func (m *BiMap[T, U]) GetFrontOrdered(keys []T) []Lookup[U] {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	res := make([]Lookup[U], len(keys))
+	for i, k := range keys {
+		res[i].Val, res[i].OK = m.front[k]
+	}
+	return res
+}
+
+// AllPresentFront reports whether all given keys exist in front map, it also returns the first missing key if not
+func (m *BiMap[T, U]) AllPresentFront(keys []T) (bool, T) {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	for _, k := range keys {
+		if _, ok := m.front[k]; !ok {
+			return false, k
+		}
+	}
+	var zero T
+	return true, zero
+}
+
 // SetFront sets the value with corresponding key in the front map, it will return an error if either key or value exist
 func (m *BiMap[T, U]) SetFront(key T, val U) error {
 	m.rwLock.Lock()
 	defer m.rwLock.Unlock()
+	return m.setFront(key, val)
+}
+
+// SetFrontIfChanged is like SetFront but it is a no-op if the pair already exists,
+// the existence is checked under the read lock first so repeated sets do not contend for the write lock
+func (m *BiMap[T, U]) SetFrontIfChanged(key T, val U) error {
+	m.rwLock.RLock()
+	v, ok := m.front[key]
+	m.rwLock.RUnlock()
+	if ok && v == val {
+		return nil
+	}
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	// the map may have changed between releasing the read lock and acquiring the write lock
+	if v, ok := m.front[key]; ok && v == val {
+		return nil
+	}
+	return m.setFront(key, val)
+}
+
+// setFront is the lock-free version of SetFront, the caller must hold the write lock
+func (m *BiMap[T, U]) setFront(key T, val U) error {
+	if err := m.checkFront(key, val); err != nil {
+		return err
+	}
+	m.set(key, val)
+	return nil
+}
+
+// checkFront returns the error setFront would return without setting the pair, the caller must hold the write lock
+func (m *BiMap[T, U]) checkFront(key T, val U) error {
+	if m.frozen {
+		return ErrFrozen
+	}
 	var ok bool
 	if _, ok = m.front[key]; !ok {
 		_, ok = m.back[val]
 	}
 	if ok {
+		m.metrics.conflict()
 		return ErrKeyValExists
 	}
-	m.front[key] = val
-	m.back[val] = key
+	return m.validate(key, val)
+}
+
+// validate runs all validators on the pair and returns the first error
+func (m *BiMap[T, U]) validate(f T, b U) error {
+	for _, fn := range m.validators {
+		if err := fn(f, b); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -81,40 +505,206 @@ func (m *BiMap[T, U]) SetFront(key T, val U) error {
 func (m *BiMap[T, U]) SetBack(key U, val T) error {
 	m.rwLock.Lock()
 	defer m.rwLock.Unlock()
-	var ok bool
-	if _, ok = m.back[key]; !ok {
-		_, ok = m.front[val]
+	return m.setFront(val, key)
+}
+
+// GetOrSetFront returns the existing value of the given key in front map with loaded set to true,
+// otherwise it sets the pair and returns val, if val already belongs to another key, the map is frozen
+// or a validator fails nothing is set and the zero value is returned
+func (m *BiMap[T, U]) GetOrSetFront(key T, val U) (actual U, loaded bool) {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	if v, ok := m.front[key]; ok {
+		return v, true
 	}
-	if ok {
-		return ErrKeyValExists
+	if _, ok := m.back[val]; ok || m.frozen {
+		m.metrics.conflict()
+		return actual, false
 	}
-	m.back[key] = val
-	m.front[val] = key
-	return nil
+	if m.validate(key, val) != nil {
+		return actual, false
+	}
+	m.set(key, val)
+	return val, false
 }
 
-// DeleteFront deletes the value of the given key in front map
-func (m *BiMap[T, _]) DeleteFront(key T) {
+// GetOrSetBack returns the existing value of the given key in back map with loaded set to true,
+// otherwise it sets the pair and returns val, if val already belongs to another key, the map is frozen
+// or a validator fails nothing is set and the zero value is returned
+func (m *BiMap[T, U]) GetOrSetBack(key U, val T) (actual T, loaded bool) {
 	m.rwLock.Lock()
 	defer m.rwLock.Unlock()
-	v, ok := m.front[key]
+	if v, ok := m.back[key]; ok {
+		return v, true
+	}
+	if _, ok := m.front[val]; ok || m.frozen {
+		m.metrics.conflict()
+		return actual, false
+	}
+	if m.validate(val, key) != nil {
+		return actual, false
+	}
+	m.set(val, key)
+	return val, false
+}
+
+// SetFrontOrReport upserts the pair in front map unless val belongs to another key, in which case it returns that key
+// without modification. The cases are:
+//   - neither key nor val exists: the pair is set
+//   - key already maps to val: nothing changes
+//   - key maps to another value and val does not exist: the value of key is replaced
+//   - val belongs to another key: nothing changes and that key is returned with conflicted set to true
+//   - the map is frozen or a validator rejects a pair that does not exist: nothing changes and the zero key is returned
+//     with conflicted set to true
+func (m *BiMap[T, U]) SetFrontOrReport(key T, val U) (conflictKey T, conflicted bool) {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	if k, ok := m.back[val]; ok {
+		if k == key {
+			return conflictKey, false
+		}
+		m.metrics.conflict()
+		return k, true
+	}
+	if m.frozen || m.validate(key, val) != nil {
+		return conflictKey, true
+	}
+	if old, ok := m.front[key]; ok {
+		m.remove(key, old)
+	}
+	m.set(key, val)
+	return conflictKey, false
+}
+
+// SetFrontDisplacing sets the pair in front map and returns the pairs removed to keep the map bijective,
+// which are at most the previous pair of key and the previous pair of val. It does nothing while frozen,
+// if a validator fails, or if the BiMap object is grow-only and val belongs to another key
+func (m *BiMap[T, U]) SetFrontDisplacing(key T, val U) []Pair[T, U] {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	if v, ok := m.front[key]; ok && v == val || m.frozen {
+		return nil
+	}
+	if m.validate(key, val) != nil {
+		return nil
+	}
+	if _, ok := m.back[val]; ok && m.growOnly {
+		return nil
+	}
+	var displaced []Pair[T, U]
+	if v, ok := m.front[key]; ok {
+		m.remove(key, v)
+		displaced = append(displaced, Pair[T, U]{Front: key, Back: v})
+	}
+	if k, ok := m.back[val]; ok {
+		m.remove(k, val)
+		displaced = append(displaced, Pair[T, U]{Front: k, Back: val})
+	}
+	m.set(key, val)
+	return displaced
+}
+
+// UpdateFront replaces the value of the given key in front map with the result of fn, it returns the new value and whether the key exists.
+// If the new value belongs to another key, a validator fails or the map is frozen, nothing changes and the old value is returned
+func (m *BiMap[T, U]) UpdateFront(key T, fn func(old U) U) (U, bool) {
+	val, ok, _ := m.updateFront(key, fn)
+	return val, ok
+}
+
+// updateFront is UpdateFront that also returns the reason the old value is kept
+func (m *BiMap[T, U]) updateFront(key T, fn func(old U) U) (U, bool, error) {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	old, ok := m.front[key]
 	if !ok {
-		return
+		var zero U
+		return zero, false, nil
 	}
-	delete(m.front, key)
-	delete(m.back, v)
+	if m.frozen {
+		return old, true, ErrFrozen
+	}
+	val := fn(old)
+	if val == old {
+		return val, true, nil
+	}
+	if _, exists := m.back[val]; exists {
+		m.metrics.conflict()
+		return old, true, ErrKeyValExists
+	}
+	if err := m.validate(key, val); err != nil {
+		return old, true, err
+	}
+	m.remove(key, old)
+	m.set(key, val)
+	return val, true, nil
+}
+
+// UpdateFrontIfPresent replaces the value of the given key in front map only if the key exists,
+// it will return an error without modification if val belongs to another key
+func (m *BiMap[T, U]) UpdateFrontIfPresent(key T, val U) (updated bool, err error) {
+	_, updated, err = m.updateFront(key, func(U) U {
+		return val
+	})
+	return updated && err == nil, err
+}
+
+// DeleteFront deletes the value of the given key in front map
+func (m *BiMap[T, _]) DeleteFront(key T) {
+	m.DeleteFrontReport(key)
 }
 
 // DeleteBack deletes the value of the given key in back map
 func (m *BiMap[_, U]) DeleteBack(key U) {
+	m.DeleteBackReport(key)
+}
+
+// DeleteFrontReport deletes the value of the given key in front map and reports whether a pair was deleted
+func (m *BiMap[T, _]) DeleteFrontReport(key T) bool {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	v, ok := m.front[key]
+	if !ok || m.frozen || m.growOnly {
+		return false
+	}
+	m.remove(key, v)
+	return true
+}
+
+// DeleteBackReport deletes the value of the given key in back map and reports whether a pair was deleted
+func (m *BiMap[_, U]) DeleteBackReport(key U) bool {
 	m.rwLock.Lock()
 	defer m.rwLock.Unlock()
 	v, ok := m.back[key]
-	if !ok {
+	if !ok || m.frozen || m.growOnly {
+		return false
+	}
+	m.remove(v, key)
+	return true
+}
+
+// Clear deletes all key-value pairs in the BiMap object
+func (m *BiMap[_, _]) Clear() {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	if m.frozen || m.growOnly {
 		return
 	}
-	delete(m.back, key)
-	delete(m.front, v)
+	for k, v := range m.front {
+		m.remove(k, v)
+	}
+}
+
+// ClearIf deletes all pairs if cond returns true for the current length, and reports whether it did
+func (m *BiMap[_, _]) ClearIf(cond func(size int) bool) bool {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	if m.frozen || m.growOnly || !cond(len(m.front)) {
+		return false
+	}
+	for k, v := range m.front {
+		m.remove(k, v)
+	}
+	return true
 }
 
 // Front returns a new map object that contains all key-value pairs in front map
@@ -139,6 +729,21 @@ func (m *BiMap[T, U]) Back() map[U]T {
 	return nm
 }
 
+// Both returns copies of front map and back map taken under the same lock so they are consistent with each other
+func (m *BiMap[T, U]) Both() (map[T]U, map[U]T) {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	front := make(map[T]U, len(m.front))
+	back := make(map[U]T, len(m.back))
+	for k, v := range m.front {
+		front[k] = v
+	}
+	for k, v := range m.back {
+		back[k] = v
+	}
+	return front, back
+}
+
 // Len returns the length of the BiMap object
 func (m *BiMap[_, _]) Len() int {
 	m.rwLock.RLock()
@@ -155,13 +760,978 @@ func (m *BiMap[T, U]) For(fn func(f T, b U)) {
 	}
 }
 
-// String returns a string representation the BiMap object
-func (m *BiMap[T, U]) String() string {
+// ForContext iterates over a copy of the map taken under the read lock, so fn runs without holding the lock
+// and may see pairs that have since changed. It checks ctx before each pair and returns ctx.Err() once it is done
+func (m *BiMap[T, U]) ForContext(ctx context.Context, fn func(f T, b U)) error {
+	m.rwLock.RLock()
+	pairs := make([]Pair[T, U], 0, len(m.front))
+	for f, b := range m.front {
+		pairs = append(pairs, Pair[T, U]{Front: f, Back: b})
+	}
+	m.rwLock.RUnlock()
+	for _, p := range pairs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		fn(p.Front, p.Back)
+	}
+	return nil
+}
+
+// ForSortedFront iterates over the front map in the key order given by less
+func (m *BiMap[T, U]) ForSortedFront(less func(a, b T) bool, fn func(f T, b U)) {
 	m.rwLock.RLock()
 	defer m.rwLock.RUnlock()
-	pairs := make([]string, 0, len(m.front))
+	keys := make([]T, 0, len(m.front))
+	for f := range m.front {
+		keys = append(keys, f)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return less(keys[i], keys[j])
+	})
+	for _, f := range keys {
+		fn(f, m.front[f])
+	}
+}
+
+// ForSortedBack iterates over the back map in the key order given by less
+func (m *BiMap[T, U]) ForSortedBack(less func(a, b U) bool, fn func(b U, f T)) {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	keys := make([]U, 0, len(m.back))
+	for b := range m.back {
+		keys = append(keys, b)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return less(keys[i], keys[j])
+	})
+	for _, b := range keys {
+		fn(b, m.back[b])
+	}
+}
+
+// ForSeeded iterates over the map in a pseudo-random order determined by seed, the order is reproducible across runs
+// for the same contents and seed as long as the %v representation of the keys is stable
+func (m *BiMap[T, U]) ForSeeded(seed int64, fn func(f T, b U)) {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	type seeded struct {
+		key  T
+		repr string
+		hash uint64
+	}
+	var prefix [8]byte
+	binary.LittleEndian.PutUint64(prefix[:], uint64(seed))
+	keys := make([]seeded, 0, len(m.front))
+	for f := range m.front {
+		repr := fmt.Sprintf("%v", f)
+		h := fnv.New64a()
+		h.Write(prefix[:])
+		h.Write([]byte(repr))
+		keys = append(keys, seeded{key: f, repr: repr, hash: h.Sum64()})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].hash != keys[j].hash {
+			return keys[i].hash < keys[j].hash
+		}
+		return keys[i].repr < keys[j].repr
+	})
+	for _, k := range keys {
+		fn(k.key, m.front[k.key])
+	}
+}
+
+// ForRemovable iterates over the map under the write lock and deletes the pairs for which fn returns true,
+// it returns the number of deleted pairs
+func (m *BiMap[T, U]) ForRemovable(fn func(f T, b U) (remove bool)) int {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	if m.frozen || m.growOnly {
+		return 0
+	}
+	n := 0
+	for f, b := range m.front {
+		if fn(f, b) {
+			m.remove(f, b)
+			n++
+		}
+	}
+	return n
+}
+
+// CompactFront deletes all pairs whose value is the zero value and returns the number of deleted pairs,
+// since values are unique it deletes at most one pair
+func (m *BiMap[T, U]) CompactFront() int {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	var zero U
+	f, ok := m.back[zero]
+	if !ok || m.frozen || m.growOnly {
+		return 0
+	}
+	m.remove(f, zero)
+	return 1
+}
+
+// RetainFront deletes all pairs whose key is not in keys and returns the number of deleted pairs
+func (m *BiMap[T, U]) RetainFront(keys []T) int {
+	keep := make(map[T]struct{}, len(keys))
+	for _, k := range keys {
+		keep[k] = struct{}{}
+	}
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	if m.frozen || m.growOnly {
+		return 0
+	}
+	n := 0
 	for f, b := range m.front {
-		pairs = append(pairs, fmt.Sprintf("%v:%v", f, b))
+		if _, ok := keep[f]; !ok {
+			m.remove(f, b)
+			n++
+		}
+	}
+	return n
+}
+
+// Partition returns two new BiMap objects, one with the pairs for which pred returns true and one with the rest
+func (m *BiMap[T, U]) Partition(pred func(f T, b U) bool) (matched, rest *BiMap[T, U]) {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	matched, rest = New[T, U](), New[T, U]()
+	for f, b := range m.front {
+		if pred(f, b) {
+			matched.set(f, b)
+		} else {
+			rest.set(f, b)
+		}
+	}
+	return matched, rest
+}
+
+// String returns a string representation the BiMap object
+func (m *BiMap[T, U]) String() string {
+	return "map[" + m.StringFunc(func(f T, b U) string {
+		return fmt.Sprintf("%v:%v", f, b)
+	}, " ") + "]"
+}
+
+// StringFunc returns a string representation of the BiMap object, each pair is formatted by format and joined by sep
+func (m *BiMap[T, U]) StringFunc(format func(f T, b U) string, sep string) string {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	pairs := make([]string, 0, len(m.front))
+	for f, b := range m.front {
+		pairs = append(pairs, format(f, b))
+	}
+	return strings.Join(pairs, sep)
+}
+
+// EqualFunc reports whether both BiMap objects contain the same keys in front map, comparing values with eq
+func (m *BiMap[T, U]) EqualFunc(other *BiMap[T, U], eq func(a, b U) bool) bool {
+	unlock := rlockPair(&m.rwLock, &other.rwLock)
+	defer unlock()
+	if len(m.front) != len(other.front) {
+		return false
+	}
+	for k, v := range m.front {
+		ov, ok := other.front[k]
+		if !ok || !eq(v, ov) {
+			return false
+		}
+	}
+	return true
+}
+
+// LastModified returns the most recently set pair. With WithInsertionOrder it returns the newest pair that is still
+// present and false only if the map is empty, otherwise only the last set key is kept and false is also returned
+// once that pair is removed
+func (m *BiMap[T, U]) LastModified() (f T, b U, ok bool) {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	if m.orderIdx != nil {
+		return m.newest()
+	}
+	if !m.hasLast {
+		return f, b, false
+	}
+	return m.last, m.front[m.last], true
+}
+
+// Entries returns all key-value pairs in front map
+func (m *BiMap[T, U]) Entries() []Entry[T, U] {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	entries := make([]Entry[T, U], 0, len(m.front))
+	for k, v := range m.front {
+		entries = append(entries, Entry[T, U]{Key: k, Value: v})
+	}
+	return entries
+}
+
+// SortedEntries returns all key-value pairs in front map sorted by key with the given less function
+func (m *BiMap[T, U]) SortedEntries(less func(a, b T) bool) []Entry[T, U] {
+	entries := m.Entries()
+	sort.Slice(entries, func(i, j int) bool {
+		return less(entries[i].Key, entries[j].Key)
+	})
+	return entries
+}
+
+// ConflictsFront returns the pairs that a batch insert such as SetFrontValidated would reject, without modifying the map.
+// A value shared by several of the pairs is reported for each of them with another of those keys as ExistingKey,
+// so an empty result means the pairs can be set together unless a validator fails
+func (m *BiMap[T, U]) ConflictsFront(pairs map[T]U) []Conflict[T, U] {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	var conflicts []Conflict[T, U]
+	// proposed holds a key for every value of pairs, and a second key if the value repeats
+	type keys struct {
+		first, second T
+		repeated      bool
+	}
+	proposed := make(map[U]keys, len(pairs))
+	for k, v := range pairs {
+		if p, ok := proposed[v]; !ok {
+			proposed[v] = keys{first: k}
+		} else if !p.repeated {
+			proposed[v] = keys{first: p.first, second: k, repeated: true}
+		}
+	}
+	for k, v := range pairs {
+		c := Conflict[T, U]{Key: k, Value: v}
+		if ev, ok := m.front[k]; ok {
+			c.Kind |= ConflictKey
+			c.ExistingValue = ev
+		}
+		if ek, ok := m.back[v]; ok {
+			c.Kind |= ConflictValue
+			c.ExistingKey = ek
+		} else if p := proposed[v]; p.repeated {
+			c.Kind |= ConflictValue
+			c.ExistingKey = p.first
+			if p.first == k {
+				c.ExistingKey = p.second
+			}
+		}
+		if c.Kind != 0 {
+			conflicts = append(conflicts, c)
+		}
+	}
+	return conflicts
+}
+
+// SetFrontValidated sets all pairs in the front map if every pair passes validate and no key or value exists,
+// otherwise nothing is set and the first error is returned
+func (m *BiMap[T, U]) SetFrontValidated(pairs map[T]U, validate func(f T, b U) error) error {
+	for k, v := range pairs {
+		if err := validate(k, v); err != nil {
+			return err
+		}
+	}
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	if err := m.checkFrontMany(pairs); err != nil {
+		return err
+	}
+	for k, v := range pairs {
+		m.set(k, v)
+	}
+	return nil
+}
+
+// checkFrontMany returns an error if any pair cannot be set in front map, including values repeated within pairs
+// and pairs rejected by validators, the caller must hold the lock
+func (m *BiMap[T, U]) checkFrontMany(pairs map[T]U) error {
+	if m.frozen {
+		return ErrFrozen
+	}
+	seen := make(map[U]struct{}, len(pairs))
+	for k, v := range pairs {
+		_, kok := m.front[k]
+		_, vok := m.back[v]
+		_, dup := seen[v]
+		if kok || vok || dup {
+			m.metrics.conflict()
+			return ErrKeyValExists
+		}
+		seen[v] = struct{}{}
+	}
+	for k, v := range pairs {
+		if err := m.validate(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Fingerprint returns an order-independent hash of all pairs, BiMap objects with the same pairs have the same fingerprint,
+// fingerprints are only comparable within the same process
+func (m *BiMap[T, U]) Fingerprint() uint64 {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	var h uint64
+	for f, b := range m.front {
+		h ^= maphash.Comparable(fingerprintSeed, struct {
+			f T
+			b U
+		}{f, b})
+	}
+	return h
+}
+
+// RebuildFront reconstructs front map from back map, treating back map as authoritative.
+// If the two maps have diverged, pairs only present in front map are lost
+func (m *BiMap[T, U]) RebuildFront() {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	m.front = make(map[T]U, len(m.back))
+	for k, v := range m.back {
+		m.front[v] = k
+	}
+}
+
+// RebuildBack reconstructs back map from front map, treating front map as authoritative.
+// If the two maps have diverged, pairs only present in back map are lost
+func (m *BiMap[T, U]) RebuildBack() {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	m.back = make(map[U]T, len(m.front))
+	for k, v := range m.front {
+		m.back[v] = k
+	}
+}
+
+// Extend sets the pairs from seq in front map one by one, it stops at the first key or value that exists
+// and returns the error along with the number of pairs added before it
+func (m *BiMap[T, U]) Extend(seq iter.Seq2[T, U]) (added int, err error) {
+	for k, v := range seq {
+		if err = m.SetFront(k, v); err != nil {
+			return added, err
+		}
+		added++
+	}
+	return added, nil
+}
+
+// MaxBack returns the pair with the largest value in front map according to less, it returns false if the map is empty
+func (m *BiMap[T, U]) MaxBack(less func(a, b U) bool) (T, U, bool) {
+	return m.extremeBack(func(a, b U) bool {
+		return less(b, a)
+	})
+}
+
+// MinBack returns the pair with the smallest value in front map according to less, it returns false if the map is empty
+func (m *BiMap[T, U]) MinBack(less func(a, b U) bool) (T, U, bool) {
+	return m.extremeBack(less)
+}
+
+// extremeBack returns the pair whose value comes first according to before
+func (m *BiMap[T, U]) extremeBack(before func(a, b U) bool) (T, U, bool) {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	var (
+		rf    T
+		rb    U
+		found bool
+	)
+	for f, b := range m.front {
+		if !found || before(b, rb) {
+			rf, rb, found = f, b, true
+		}
+	}
+	return rf, rb, found
+}
+
+// KeyDiff returns the keys in front map that only exist in the BiMap object and those that only exist in other
+func (m *BiMap[T, U]) KeyDiff(other *BiMap[T, U]) (onlyHere, onlyThere []T) {
+	unlock := rlockPair(&m.rwLock, &other.rwLock)
+	defer unlock()
+	for k := range m.front {
+		if _, ok := other.front[k]; !ok {
+			onlyHere = append(onlyHere, k)
+		}
+	}
+	for k := range other.front {
+		if _, ok := m.front[k]; !ok {
+			onlyThere = append(onlyThere, k)
+		}
+	}
+	return onlyHere, onlyThere
+}
+
+// Exchange replaces all pairs with the given pairs and returns the previous front map,
+// it will return an error without modification if a value repeats in pairs or a validator fails
+func (m *BiMap[T, U]) Exchange(pairs map[T]U) (old map[T]U, err error) {
+	if ok, _ := IsInjective(pairs); !ok {
+		return nil, ErrKeyValExists
+	}
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	old = make(map[T]U, len(m.front))
+	for k, v := range m.front {
+		old[k] = v
+	}
+	if err := m.replaceChecked(pairs); err != nil {
+		return nil, err
+	}
+	return old, nil
+}
+
+// Orphans returns the front keys whose pair is missing from back map and the back keys whose pair is missing from front map,
+// both are empty unless the maps have diverged
+func (m *BiMap[T, U]) Orphans() (frontOnly []T, backOnly []U) {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	for k, v := range m.front {
+		if f, ok := m.back[v]; !ok || f != k {
+			frontOnly = append(frontOnly, k)
+		}
+	}
+	for k, v := range m.back {
+		if b, ok := m.front[v]; !ok || b != k {
+			backOnly = append(backOnly, k)
+		}
+	}
+	return frontOnly, backOnly
+}
+
+// CheckInvariant returns an error wrapping ErrInvariant if front map and back map are not inverses of each other
+func (m *BiMap[T, U]) CheckInvariant() error {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	return m.checkInvariant()
+}
+
+// checkInvariant is CheckInvariant without locking, the caller must hold the lock
+func (m *BiMap[T, U]) checkInvariant() error {
+	if len(m.front) != len(m.back) {
+		return fmt.Errorf("%w: %d front keys, %d back keys", ErrInvariant, len(m.front), len(m.back))
+	}
+	for k, v := range m.front {
+		if f, ok := m.back[v]; !ok || f != k {
+			return fmt.Errorf("%w: key %v, value %v", ErrInvariant, k, v)
+		}
+	}
+	return nil
+}
+
+// MutateRaw calls fn under the write lock with the internal maps for bulk edits, fn must keep back map the inverse
+// of front map and MutateRaw panics with an error wrapping ErrInvariant if it does not, or with ErrGrowOnly if fn
+// removes a key of a grow-only map, after restoring the previous pairs. Validators are not run, the pairs that changed
+// are reported to observers, metrics, change tracking and insertion order as deletions followed by sets in no particular
+// order, and fn is not called while frozen. The pairs are copied before fn is called to find the changes
+func (m *BiMap[T, U]) MutateRaw(fn func(front map[T]U, back map[U]T)) {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	if m.frozen {
+		return
+	}
+	old := make(map[T]U, len(m.front))
+	for k, v := range m.front {
+		old[k] = v
+	}
+	fn(m.front, m.back)
+	err := m.checkInvariant()
+	for k := range old {
+		if _, ok := m.front[k]; !ok && err == nil && m.growOnly {
+			err = ErrGrowOnly
+		}
+	}
+	if err != nil {
+		m.front = old
+		m.back = make(map[U]T, len(old))
+		for k, v := range old {
+			m.back[v] = k
+		}
+		panic(err)
+	}
+	for k, v := range old {
+		if nv, ok := m.front[k]; !ok || nv != v {
+			m.onRemove(k, v)
+		}
+	}
+	for k, v := range m.front {
+		if ov, ok := old[k]; !ok || ov != v {
+			m.onSet(k, v)
+		}
+	}
+}
+
+// MoveFront moves the pair of the given key in front map from src to dst, it will return an error without modification
+// if the key does not exist in src, either key or value exists in dst or a validator of dst fails
+func MoveFront[T, U comparable](src, dst *BiMap[T, U], key T) error {
+	unlock := lockPair(&src.rwLock, &dst.rwLock)
+	defer unlock()
+	if src.frozen || dst.frozen {
+		return ErrFrozen
+	}
+	if src.growOnly {
+		return ErrGrowOnly
+	}
+	v, ok := src.front[key]
+	if !ok {
+		return ErrKeyNotExists
+	}
+	if _, ok := dst.front[key]; ok {
+		dst.metrics.conflict()
+		return ErrKeyValExists
+	}
+	if _, ok := dst.back[v]; ok {
+		dst.metrics.conflict()
+		return ErrKeyValExists
+	}
+	if err := dst.validate(key, v); err != nil {
+		return err
+	}
+	src.remove(key, v)
+	dst.set(key, v)
+	return nil
+}
+
+// SymmetricDiff returns a new BiMap object with the pairs that exist in exactly one of the two BiMap objects.
+// A pair only in other is dropped if its key or value collides with a pair only in the BiMap object
+func (m *BiMap[T, U]) SymmetricDiff(other *BiMap[T, U]) *BiMap[T, U] {
+	unlock := rlockPair(&m.rwLock, &other.rwLock)
+	defer unlock()
+	res := New[T, U]()
+	for k, v := range m.front {
+		if ov, ok := other.front[k]; !ok || ov != v {
+			res.set(k, v)
+		}
+	}
+	for k, v := range other.front {
+		if mv, ok := m.front[k]; ok && mv == v {
+			continue
+		}
+		res.setFront(k, v)
+	}
+	return res
+}
+
+// Sample returns up to n arbitrary pairs
+func (m *BiMap[T, U]) Sample(n int) []Pair[T, U] {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	if n > len(m.front) {
+		n = len(m.front)
+	}
+	if n <= 0 {
+		return nil
+	}
+	pairs := make([]Pair[T, U], 0, n)
+	for f, b := range m.front {
+		pairs = append(pairs, Pair[T, U]{Front: f, Back: b})
+		if len(pairs) == n {
+			break
+		}
+	}
+	return pairs
+}
+
+// ApproxSizeBytes returns a rough estimate of the memory used by both maps. It assumes each map keeps its slots
+// at most 7/8 full in groups of 8, with one control byte per slot and a fixed header. Only the inline sizes of
+// T and U are counted, memory referenced by strings, pointers, slices and other reference types is not included
+func (m *BiMap[T, U]) ApproxSizeBytes() int {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	var (
+		f T
+		b U
+	)
+	pair := int(unsafe.Sizeof(f) + unsafe.Sizeof(b))
+	return int(unsafe.Sizeof(*m)) + approxMapSize(len(m.front), pair) + approxMapSize(len(m.back), pair)
+}
+
+// approxMapSize estimates the memory used by a map with n entries whose key and value take pair bytes
+func approxMapSize(n, pair int) int {
+	const (
+		header    = 48
+		groupSize = 8
+	)
+	slots := (n*8 + 6) / 7
+	slots = (slots + groupSize - 1) / groupSize * groupSize
+	return header + slots*(pair+1)
+}
+
+// MergeWith sets the pairs of src in dst, the values of keys in both are combined with combine(dst value, src value),
+// it will return an error without modification if the result would have repeated values
+func MergeWith[T, U comparable](dst, src *BiMap[T, U], combine func(a, b U) U) error {
+	unlock := lockPair(&dst.rwLock, &src.rwLock)
+	defer unlock()
+	if dst.frozen {
+		return ErrFrozen
+	}
+	changes := make(map[T]U, len(src.front))
+	for k, v := range src.front {
+		if dv, ok := dst.front[k]; ok {
+			v = combine(dv, v)
+		}
+		changes[k] = v
+	}
+	owners := make(map[U]T, len(dst.back)+len(changes))
+	for v, k := range dst.back {
+		if _, ok := changes[k]; !ok {
+			owners[v] = k
+		}
+	}
+	for k, v := range changes {
+		if _, ok := owners[v]; ok {
+			dst.metrics.conflict()
+			return ErrKeyValExists
+		}
+		if err := dst.validate(k, v); err != nil {
+			return err
+		}
+		owners[v] = k
+	}
+	for k := range changes {
+		if v, ok := dst.front[k]; ok {
+			dst.remove(k, v)
+		}
+	}
+	for k, v := range changes {
+		dst.set(k, v)
+	}
+	return nil
+}
+
+// GroupBy returns all pairs grouped by the key that keyfn derives from each pair,
+// the pairs are copied under the read lock before keyfn is called
+func GroupBy[T, U, K comparable](m *BiMap[T, U], keyfn func(f T, b U) K) map[K][]Pair[T, U] {
+	m.rwLock.RLock()
+	pairs := make([]Pair[T, U], 0, len(m.front))
+	for f, b := range m.front {
+		pairs = append(pairs, Pair[T, U]{Front: f, Back: b})
+	}
+	m.rwLock.RUnlock()
+	groups := make(map[K][]Pair[T, U])
+	for _, p := range pairs {
+		k := keyfn(p.Front, p.Back)
+		groups[k] = append(groups[k], p)
+	}
+	return groups
+}
+
+// AssertFront returns an error describing the difference if the given key in front map is absent or not mapped to want
+func (m *BiMap[T, U]) AssertFront(key T, want U) error {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	got, ok := m.front[key]
+	if !ok {
+		return fmt.Errorf("%w: key %v, want value %v", ErrKeyNotExists, key, want)
+	}
+	if got != want {
+		return fmt.Errorf("%w: key %v, want value %v, got %v", ErrValueMismatch, key, want, got)
+	}
+	return nil
+}
+
+// ValidateNoZeroValues returns an error wrapping ErrZeroValue with the key whose value is the zero value,
+// since values are unique at most one key can have it
+func (m *BiMap[T, U]) ValidateNoZeroValues() error {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	var zero U
+	if f, ok := m.back[zero]; ok {
+		return fmt.Errorf("%w: key %v", ErrZeroValue, f)
+	}
+	return nil
+}
+
+// InvertFiltered returns a new BiMap object with the pairs for which pred returns true, with front and back swapped
+func InvertFiltered[T, U comparable](m *BiMap[T, U], pred func(f T, b U) bool) *BiMap[U, T] {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	res := New[U, T]()
+	for f, b := range m.front {
+		if pred(f, b) {
+			res.set(b, f)
+		}
+	}
+	return res
+}
+
+// KeysWhere returns the keys in front map whose values satisfy pred
+func (m *BiMap[T, U]) KeysWhere(pred func(b U) bool) []T {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	var keys []T
+	for f, b := range m.front {
+		if pred(b) {
+			keys = append(keys, f)
+		}
+	}
+	return keys
+}
+
+// AreInverses reports whether the front map of a equals the back map of b, that is b maps every value of a back to its key
+func AreInverses[T, U comparable](a *BiMap[T, U], b *BiMap[U, T]) bool {
+	unlock := rlockPair(&a.rwLock, &b.rwLock)
+	defer unlock()
+	if len(a.front) != len(b.back) || len(a.back) != len(b.front) {
+		return false
+	}
+	for k, v := range a.front {
+		if bv, ok := b.back[k]; !ok || bv != v {
+			return false
+		}
+	}
+	for k, v := range b.front {
+		if av, ok := a.back[k]; !ok || av != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Compose returns a BiMap object that maps each key of a to the value that b maps its value to,
+// keys whose value does not exist in b are left out. Since both maps are bijective the result is too,
+// an error is only returned if either map has diverged
+func Compose[T, U, V comparable](a *BiMap[T, U], b *BiMap[U, V]) (*BiMap[T, V], error) {
+	unlock := rlockPair(&a.rwLock, &b.rwLock)
+	defer unlock()
+	res := New[T, V]()
+	for t, u := range a.front {
+		v, ok := b.front[u]
+		if !ok {
+			continue
+		}
+		if err := res.setFront(t, v); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// ChainLength follows start to its value, then that value as a key and so on, and returns the number of steps taken.
+// It stops when a value is not a key or a key is visited again, the step back to a visited key is counted,
+// so a start on a cycle of n pairs returns n, and a start that is not a key returns 0
+func ChainLength[T comparable](m *BiMap[T, T], start T) int {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	visited := map[T]struct{}{start: {}}
+	n := 0
+	for cur := start; ; {
+		next, ok := m.front[cur]
+		if !ok {
+			return n
+		}
+		n++
+		if _, ok := visited[next]; ok {
+			return n
+		}
+		visited[next] = struct{}{}
+		cur = next
+	}
+}
+
+// ValueHistogram returns the number of values in front map that fall into each bucket
+func ValueHistogram[T, U, K comparable](m *BiMap[T, U], bucket func(U) K) map[K]int {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	hist := make(map[K]int)
+	for b := range m.back {
+		hist[bucket(b)]++
+	}
+	return hist
+}
+
+// InitOnce sets the pairs returned by fn in front map, fn is only called by the first call and later calls are no-ops.
+// Every call returns the error of the first call, nothing is set if a key or value of the pairs exists or repeats
+func (m *BiMap[T, U]) InitOnce(fn func() map[T]U) error {
+	m.initOnce.Do(func() {
+		pairs := fn()
+		m.rwLock.Lock()
+		defer m.rwLock.Unlock()
+		if m.initErr = m.checkFrontMany(pairs); m.initErr != nil {
+			return
+		}
+		for k, v := range pairs {
+			m.set(k, v)
+		}
+	})
+	return m.initErr
+}
+
+// CountBy returns the number of pairs for which pred returns true and the number of the rest
+func (m *BiMap[T, U]) CountBy(pred func(f T, b U) bool) (matched, unmatched int) {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	for f, b := range m.front {
+		if pred(f, b) {
+			matched++
+		} else {
+			unmatched++
+		}
+	}
+	return matched, unmatched
+}
+
+// CountBackFunc returns the number of pairs for which pred returns true, iterating the back map
+func (m *BiMap[T, U]) CountBackFunc(pred func(b U, f T) bool) int {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	n := 0
+	for b, f := range m.back {
+		if pred(b, f) {
+			n++
+		}
+	}
+	return n
+}
+
+// Reload replaces all pairs with the pairs from seq, the iterator is drained before the write lock is taken
+// and the old pairs are kept if a key or value repeats
+func (m *BiMap[T, U]) Reload(seq iter.Seq2[T, U]) error {
+	tmp := New[T, U]()
+	for k, v := range seq {
+		if err := tmp.setFront(k, v); err != nil {
+			return err
+		}
+	}
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	return m.replaceChecked(tmp.front)
+}
+
+// TypeInfo returns the names of the front and back key types
+func (m *BiMap[T, U]) TypeInfo() (frontType, backType string) {
+	return reflect.TypeFor[T]().String(), reflect.TypeFor[U]().String()
+}
+
+// Neighbors returns the other keys in front map whose values satisfy sameGroup with the value of the given key,
+// it returns nil if the key does not exist
+func (m *BiMap[T, U]) Neighbors(key T, sameGroup func(a, b U) bool) []T {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	v, ok := m.front[key]
+	if !ok {
+		return nil
+	}
+	var keys []T
+	for f, b := range m.front {
+		if f != key && sameGroup(v, b) {
+			keys = append(keys, f)
+		}
+	}
+	return keys
+}
+
+// GoLiteral returns the front map as a Go composite literal with the entries sorted by their formatted keys,
+// keys and values are formatted with %#v so strings are quoted
+func (m *BiMap[T, U]) GoLiteral() string {
+	m.rwLock.RLock()
+	entries := make([][2]string, 0, len(m.front))
+	for f, b := range m.front {
+		entries = append(entries, [2]string{fmt.Sprintf("%#v", f), fmt.Sprintf("%#v", b)})
+	}
+	m.rwLock.RUnlock()
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i][0] < entries[j][0]
+	})
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%T{", map[T]U(nil))
+	for i, e := range entries {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(e[0] + ": " + e[1])
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// Overlay returns a new BiMap object with the pairs of the BiMap object and the pairs of base whose key it lacks.
+// A pair of base is dropped if its value already belongs to another key in the result
+func (m *BiMap[T, U]) Overlay(base *BiMap[T, U]) *BiMap[T, U] {
+	unlock := rlockPair(&m.rwLock, &base.rwLock)
+	defer unlock()
+	res := New[T, U]()
+	for k, v := range m.front {
+		res.set(k, v)
+	}
+	for k, v := range base.front {
+		res.setFront(k, v)
+	}
+	return res
+}
+
+// NoKeyValueOverlap returns every x that is both a key and a value in front map, in the same pair or in different pairs
+func NoKeyValueOverlap[T comparable](m *BiMap[T, T]) (overlap []T) {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	for f := range m.front {
+		if _, ok := m.back[f]; ok {
+			overlap = append(overlap, f)
+		}
+	}
+	return overlap
+}
+
+// RenameFrontKeys renames the keys in front map from the keys to the values of mapping, keeping their values.
+// It will return an error without modification if an old key does not exist, two old keys are renamed to the same key,
+// or a new key exists and is not renamed itself
+func (m *BiMap[T, U]) RenameFrontKeys(mapping map[T]T) error {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	if m.frozen {
+		return ErrFrozen
+	}
+	seen := make(map[T]struct{}, len(mapping))
+	for old, nk := range mapping {
+		v, ok := m.front[old]
+		if !ok {
+			return ErrKeyNotExists
+		}
+		if _, ok := seen[nk]; ok {
+			m.metrics.conflict()
+			return ErrKeyValExists
+		}
+		seen[nk] = struct{}{}
+		if _, ok := m.front[nk]; ok {
+			if _, renamed := mapping[nk]; !renamed {
+				m.metrics.conflict()
+				return ErrKeyValExists
+			}
+		}
+		if err := m.validate(nk, v); err != nil {
+			return err
+		}
+	}
+	if m.growOnly {
+		for old := range mapping {
+			if _, ok := seen[old]; !ok {
+				return ErrGrowOnly
+			}
+		}
+	}
+	vals := make(map[T]U, len(mapping))
+	for old, nk := range mapping {
+		v := m.front[old]
+		vals[nk] = v
+		m.remove(old, v)
+	}
+	for nk, v := range vals {
+		m.set(nk, v)
+	}
+	return nil
+}
+
+// Similarity returns the Jaccard index of the pairs of both BiMap objects, the number of shared pairs divided by
+// the number of distinct pairs, two empty BiMap objects are considered identical
+func (m *BiMap[T, U]) Similarity(other *BiMap[T, U]) float64 {
+	unlock := rlockPair(&m.rwLock, &other.rwLock)
+	defer unlock()
+	shared := 0
+	for k, v := range m.front {
+		if ov, ok := other.front[k]; ok && ov == v {
+			shared++
+		}
+	}
+	union := len(m.front) + len(other.front) - shared
+	if union == 0 {
+		return 1
 	}
-	return "map[" + strings.Join(pairs, " ") + "]"
+	return float64(shared) / float64(union)
 }
@@ -1,6 +1,43 @@
 package bimap
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"go/parser"
+	"maps"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"text/template"
+)
+
+type user struct {
+	ID   int
+	Name string
+}
+
+func TestIndex(t *testing.T) {
+	id := func(u user) int {
+		return u.ID
+	}
+	name := func(u user) string {
+		return u.Name
+	}
+	m, err := Index([]user{{1, "alice"}, {2, "bob"}}, id, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key, _ := m.GetBack("bob"); key != 2 {
+		t.Errorf("Values not equal, want: %d, got: %d", 2, key)
+	}
+	if _, err := Index([]user{{1, "alice"}, {2, "alice"}}, id, name); err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+}
 
 func TestGetSetFront(t *testing.T) {
 	m := New[string, string]()
@@ -48,6 +85,264 @@ func TestDeleteBack(t *testing.T) {
 	}
 }
 
+func TestUpdateFrontIfPresent(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	if updated, err := m.UpdateFrontIfPresent("z", 3); updated || err != nil {
+		t.Errorf("Absent key should be a no-op, got: %v, %v", updated, err)
+	}
+	if _, ok := m.GetFront("z"); ok {
+		t.Error("Absent key should not be inserted")
+	}
+	if updated, err := m.UpdateFrontIfPresent("a", 10); !updated || err != nil {
+		t.Errorf("Present key should be updated, got: %v, %v", updated, err)
+	}
+	if key, _ := m.GetBack(10); key != "a" {
+		t.Errorf("Values not equal, want: %s, got: %s", "a", key)
+	}
+	if updated, err := m.UpdateFrontIfPresent("a", 2); updated || err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+}
+
+func TestDeleteReport(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	if !m.DeleteFrontReport("a") {
+		t.Error("Should report deletion")
+	}
+	if m.DeleteFrontReport("a") {
+		t.Error("Should not report deletion of absent key")
+	}
+	if !m.DeleteBackReport(2) {
+		t.Error("Should report deletion")
+	}
+	if m.DeleteBackReport(2) {
+		t.Error("Should not report deletion of absent key")
+	}
+	if l := m.Len(); l != 0 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 0, l)
+	}
+}
+
+func TestClear(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	m.Clear()
+	if l := m.Len(); l != 0 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 0, l)
+	}
+	if _, ok := m.GetBack(1); ok {
+		t.Error("Should be deleted")
+	}
+}
+
+func TestForContext(t *testing.T) {
+	m := newBenchMap(10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	n := 0
+	err := m.ForContext(ctx, func(f string, b int) {
+		n++
+		if n == 3 {
+			cancel()
+		}
+	})
+	if err != context.Canceled {
+		t.Errorf("Errors not equal, want: %v, got: %v", context.Canceled, err)
+	}
+	if n != 3 {
+		t.Errorf("Counts not equal, want: %d, got: %d", 3, n)
+	}
+	n = 0
+	if err := m.ForContext(context.Background(), func(string, int) { n++ }); err != nil || n != 10 {
+		t.Errorf("Should visit all pairs, visited: %d, err: %v", n, err)
+	}
+}
+
+func TestForSortedFront(t *testing.T) {
+	m := New(WithInitialMap(map[int]string{3: "c", 1: "a", 2: "b"}))
+	var got []int
+	m.ForSortedFront(func(a, b int) bool {
+		return a < b
+	}, func(f int, b string) {
+		got = append(got, f)
+	})
+	if len(got) != 3 {
+		t.Fatalf("Lengths not equal, want: %d, got: %d", 3, len(got))
+	}
+	for i, f := range got {
+		if f != i+1 {
+			t.Errorf("Keys not equal at %d, want: %d, got: %d", i, i+1, f)
+		}
+	}
+}
+
+func TestForSortedBack(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"c": 30, "a": 10, "b": 20}))
+	var got []string
+	m.ForSortedBack(func(a, b int) bool {
+		return a > b
+	}, func(b int, f string) {
+		got = append(got, strconv.Itoa(b)+f)
+	})
+	want := []string{"30c", "20b", "10a"}
+	if len(got) != len(want) {
+		t.Fatalf("Lengths not equal, want: %d, got: %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Pairs not equal at %d, want: %s, got: %s", i, want[i], got[i])
+		}
+	}
+}
+
+func TestForSeeded(t *testing.T) {
+	src := make(map[int]string)
+	for i := 0; i < 50; i++ {
+		src[i] = strconv.Itoa(i)
+	}
+	order := func(m *BiMap[int, string], seed int64) []int {
+		var keys []int
+		m.ForSeeded(seed, func(f int, b string) {
+			keys = append(keys, f)
+		})
+		return keys
+	}
+	first := order(New(WithInitialMap(src)), 42)
+	second := order(New(WithInitialMap(src)), 42)
+	if len(first) != len(src) {
+		t.Fatalf("Lengths not equal, want: %d, got: %d", len(src), len(first))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Orders differ at %d, want: %d, got: %d", i, first[i], second[i])
+		}
+	}
+	other := order(New(WithInitialMap(src)), 7)
+	same := true
+	for i := range first {
+		if first[i] != other[i] {
+			same = false
+			break
+		}
+	}
+	if same {
+		t.Error("Different seeds should give different orders")
+	}
+}
+
+func TestForRemovable(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}))
+	n := m.ForRemovable(func(f string, b int) bool {
+		return b%2 == 0
+	})
+	if n != 2 {
+		t.Errorf("Counts not equal, want: %d, got: %d", 2, n)
+	}
+	for _, k := range []string{"b", "d"} {
+		if _, ok := m.GetFront(k); ok {
+			t.Errorf("Key %s should be deleted", k)
+		}
+	}
+	for _, v := range []int{1, 3} {
+		if _, ok := m.GetBack(v); !ok {
+			t.Errorf("Value %d should exist", v)
+		}
+	}
+	if _, ok := m.GetBack(2); ok {
+		t.Error("Should be deleted")
+	}
+}
+
+func TestCompactFront(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 0, "b": 2, "c": 3}))
+	if n := m.CompactFront(); n != 1 {
+		t.Errorf("Counts not equal, want: %d, got: %d", 1, n)
+	}
+	if _, ok := m.GetFront("a"); ok {
+		t.Error("Zero value pair should be deleted")
+	}
+	if l := m.Len(); l != 2 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 2, l)
+	}
+	if n := m.CompactFront(); n != 0 {
+		t.Errorf("Counts not equal, want: %d, got: %d", 0, n)
+	}
+}
+
+func TestRetainFront(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}))
+	if n := m.RetainFront([]string{"a", "c", "z"}); n != 2 {
+		t.Errorf("Counts not equal, want: %d, got: %d", 2, n)
+	}
+	front := m.Front()
+	if len(front) != 2 || front["a"] != 1 || front["c"] != 3 {
+		t.Errorf("Unexpected result: %v", front)
+	}
+	if _, ok := m.GetBack(2); ok {
+		t.Error("Should be deleted")
+	}
+}
+
+func TestPartition(t *testing.T) {
+	src := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5}
+	m := New(WithInitialMap(src))
+	matched, rest := m.Partition(func(f string, b int) bool {
+		return b > 2
+	})
+	if matched.Len()+rest.Len() != m.Len() {
+		t.Errorf("Lengths not equal, want: %d, got: %d", m.Len(), matched.Len()+rest.Len())
+	}
+	for k, v := range src {
+		mv, inMatched := matched.GetFront(k)
+		rv, inRest := rest.GetFront(k)
+		if inMatched == inRest {
+			t.Errorf("Key %s should be in exactly one partition", k)
+		}
+		if inMatched && mv != v || inRest && rv != v {
+			t.Errorf("Values not equal for key %s", k)
+		}
+	}
+	if m.Len() != len(src) {
+		t.Error("Original should be untouched")
+	}
+}
+
+func TestBoth(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2, "c": 3}))
+	front, back := m.Both()
+	if len(front) != len(back) {
+		t.Fatalf("Lengths not equal, want: %d, got: %d", len(front), len(back))
+	}
+	for k, v := range front {
+		if back[v] != k {
+			t.Errorf("Values not equal, want: %s, got: %s", k, back[v])
+		}
+	}
+	front["d"] = 4
+	if _, ok := m.GetFront("d"); ok {
+		t.Error("Returned map should be a copy")
+	}
+}
+
+func TestClearIf(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	overCap := func(size int) bool {
+		return size > 2
+	}
+	if m.ClearIf(overCap) {
+		t.Error("Should not clear under the cap")
+	}
+	if l := m.Len(); l != 2 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 2, l)
+	}
+	m.SetFront("c", 3)
+	if !m.ClearIf(overCap) {
+		t.Error("Should clear over the cap")
+	}
+	if l := m.Len(); l != 0 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 0, l)
+	}
+}
+
 func TestNewWithInitialMap(t *testing.T) {
 	k, v := "k", "v"
 	m := New(WithInitialMap(map[string]string{
@@ -57,3 +352,1377 @@ func TestNewWithInitialMap(t *testing.T) {
 		t.Errorf("Values not equal, want: %s, got: %s", v, val)
 	}
 }
+
+func TestWithInitialMapCopy(t *testing.T) {
+	src := map[string]int{"a": 1}
+	opt := WithInitialMap(src)
+	src["b"] = 2
+	m := New(opt)
+	if _, ok := m.GetFront("b"); ok {
+		t.Error("Changes before New should not affect the option")
+	}
+	src["a"] = 10
+	delete(src, "b")
+	if val, _ := m.GetFront("a"); val != 1 {
+		t.Errorf("Values not equal, want: %d, got: %d", 1, val)
+	}
+}
+
+func TestReuseOptions(t *testing.T) {
+	opts := []option[string, int]{WithInitialMap(map[string]int{"a": 1})}
+	m1 := New(opts...)
+	m2 := New(opts...)
+	m1.SetFront("b", 2)
+	m2.DeleteFront("a")
+	if _, ok := m2.GetFront("b"); ok {
+		t.Error("Changes to one BiMap should not affect the other")
+	}
+	if _, ok := m1.GetFront("a"); !ok {
+		t.Error("Changes to one BiMap should not affect the other")
+	}
+	m3 := New(opts...)
+	if front := m3.Front(); len(front) != 1 || front["a"] != 1 {
+		t.Errorf("Unexpected contents: %v", front)
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	a := New(WithInitialMap(map[string]float64{"x": 1.0, "y": 2.0}))
+	b := New(WithInitialMap(map[string]float64{"x": 1.0000001, "y": 1.9999999}))
+	eq := func(a, b float64) bool {
+		return math.Abs(a-b) < 1e-6
+	}
+	if !a.EqualFunc(b, eq) {
+		t.Error("Should be equal within tolerance")
+	}
+	b.SetFront("z", 3.0)
+	if a.EqualFunc(b, eq) {
+		t.Error("Should not be equal with different keys")
+	}
+	c := New(WithInitialMap(map[string]float64{"x": 1.0, "y": 2.1}))
+	if a.EqualFunc(c, eq) {
+		t.Error("Should not be equal outside tolerance")
+	}
+}
+
+func TestLastModified(t *testing.T) {
+	m := New[string, int]()
+	if _, _, ok := m.LastModified(); ok {
+		t.Error("Should be empty")
+	}
+	m.SetFront("a", 1)
+	m.SetFront("b", 2)
+	m.SetBack(3, "c")
+	f, b, ok := m.LastModified()
+	if !ok || f != "c" || b != 3 {
+		t.Errorf("Pairs not equal, want: c:3, got: %s:%d", f, b)
+	}
+	m.DeleteFront("c")
+	if _, _, ok := m.LastModified(); ok {
+		t.Error("Should be cleared after deletion without insertion order")
+	}
+}
+
+func TestLastModifiedInsertionOrder(t *testing.T) {
+	m := New(WithInsertionOrder[string, int]())
+	m.SetFront("a", 1)
+	m.SetFront("b", 2)
+	m.SetBack(3, "c")
+	m.DeleteFront("c")
+	f, b, ok := m.LastModified()
+	if !ok || f != "b" || b != 2 {
+		t.Errorf("Pairs not equal, want: b:2, got: %s:%d", f, b)
+	}
+	m.UpdateFront("a", func(old int) int { return old + 10 })
+	m.DeleteFront("b")
+	f, b, ok = m.LastModified()
+	if !ok || f != "a" || b != 11 {
+		t.Errorf("Pairs not equal, want: a:11, got: %s:%d", f, b)
+	}
+	m.DeleteFront("a")
+	if _, _, ok := m.LastModified(); ok {
+		t.Error("Should be empty")
+	}
+	initial := New(WithInitialMap(map[string]int{"x": 1}), WithInsertionOrder[string, int]())
+	if f, _, ok := initial.LastModified(); !ok || f != "x" {
+		t.Errorf("Values not equal, want: %v, got: %v", "x", f)
+	}
+}
+
+func TestIsInjective(t *testing.T) {
+	if ok, _ := IsInjective(map[string]int{"a": 1, "b": 2}); !ok {
+		t.Error("Should be injective")
+	}
+	ok, dup := IsInjective(map[string]int{"a": 1, "b": 2, "c": 2})
+	if ok {
+		t.Error("Should not be injective")
+	}
+	if dup != 2 {
+		t.Errorf("Values not equal, want: %d, got: %d", 2, dup)
+	}
+}
+
+func TestNewFromSlices(t *testing.T) {
+	m, err := NewFromSlices([]string{"a", "b"}, []int{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val, _ := m.GetBack(2); val != "b" {
+		t.Errorf("Values not equal, want: %s, got: %s", "b", val)
+	}
+	if _, err := NewFromSlices([]string{"a", "b"}, []int{1}); err != ErrLengthMismatch {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrLengthMismatch, err)
+	}
+	if _, err := NewFromSlices([]string{"a", "b"}, []int{1, 1}); err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+}
+
+func TestGetOrZero(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1}))
+	if val := m.GetFrontOrZero("a"); val != 1 {
+		t.Errorf("Values not equal, want: %d, got: %d", 1, val)
+	}
+	if val := m.GetFrontOrZero("b"); val != 0 {
+		t.Errorf("Values not equal, want: %d, got: %d", 0, val)
+	}
+	if val := m.GetBackOrZero(1); val != "a" {
+		t.Errorf("Values not equal, want: %s, got: %s", "a", val)
+	}
+	if val := m.GetBackOrZero(2); val != "" {
+		t.Errorf("Values not equal, want: %q, got: %q", "", val)
+	}
+}
+
+func TestAllPresentFront(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	if ok, _ := m.AllPresentFront([]string{"a", "b"}); !ok {
+		t.Error("All keys should exist")
+	}
+	ok, missing := m.AllPresentFront([]string{"a", "c", "d"})
+	if ok {
+		t.Error("Key should not exist")
+	}
+	if missing != "c" {
+		t.Errorf("Keys not equal, want: %s, got: %s", "c", missing)
+	}
+}
+
+func TestUpdateFront(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 5}))
+	inc := func(old int) int {
+		return old + 1
+	}
+	val, ok := m.UpdateFront("a", inc)
+	if !ok || val != 2 {
+		t.Errorf("Values not equal, want: %d, got: %d", 2, val)
+	}
+	if key, _ := m.GetBack(2); key != "a" {
+		t.Errorf("Values not equal, want: %s, got: %s", "a", key)
+	}
+	if _, ok := m.GetBack(1); ok {
+		t.Error("Old value should be deleted")
+	}
+	if _, ok := m.UpdateFront("c", inc); ok {
+		t.Error("Key should not exist")
+	}
+	m.SetFront("d", 4)
+	if val, ok := m.UpdateFront("d", inc); !ok || val != 4 {
+		t.Errorf("Values not equal, want: %d, got: %d", 4, val)
+	}
+	if val, _ := m.GetFront("d"); val != 4 {
+		t.Errorf("Values not equal, want: %d, got: %d", 4, val)
+	}
+}
+
+func TestSetFrontIfChanged(t *testing.T) {
+	m := New[string, int]()
+	if err := m.SetFrontIfChanged("a", 1); err != nil {
+		t.Error(err)
+	}
+	if err := m.SetFrontIfChanged("a", 1); err != nil {
+		t.Error(err)
+	}
+	if err := m.SetFrontIfChanged("a", 2); err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+	if err := m.SetFrontIfChanged("b", 1); err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+}
+
+func newBenchMap(n int) *BiMap[string, int] {
+	m := New[string, int]()
+	for i := 0; i < n; i++ {
+		m.SetFront(strconv.Itoa(i), i)
+	}
+	return m
+}
+
+func BenchmarkSetFrontIdempotent(b *testing.B) {
+	m := newBenchMap(1024)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.SetFront(strconv.Itoa(i%1024), i%1024)
+			i++
+		}
+	})
+}
+
+func BenchmarkSetFrontIfChangedIdempotent(b *testing.B) {
+	m := newBenchMap(1024)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.SetFrontIfChanged(strconv.Itoa(i%1024), i%1024)
+			i++
+		}
+	})
+}
+
+func BenchmarkSetDeleteFront(b *testing.B) {
+	m := New[int, int]()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.SetFront(i, i)
+		m.DeleteFront(i)
+	}
+}
+
+func TestEntries(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"b": 2, "c": 3, "a": 1}))
+	if l := len(m.Entries()); l != 3 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 3, l)
+	}
+	tmpl := template.Must(template.New("").Parse("{{range .}}{{.Key}}={{.Value}};{{end}}"))
+	less := func(a, b string) bool {
+		return a < b
+	}
+	want := "a=1;b=2;c=3;"
+	for i := 0; i < 5; i++ {
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, m.SortedEntries(less)); err != nil {
+			t.Fatal(err)
+		}
+		if got := sb.String(); got != want {
+			t.Errorf("Outputs not equal, want: %s, got: %s", want, got)
+		}
+	}
+}
+
+func TestStringFunc(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"k": 1}))
+	if s := m.String(); s != "map[k:1]" {
+		t.Errorf("Strings not equal, want: %s, got: %s", "map[k:1]", s)
+	}
+	m.SetFront("k2", 2)
+	s := m.StringFunc(func(f string, b int) string {
+		return f + "=" + strconv.Itoa(b)
+	}, "; ")
+	if s != "k=1; k2=2" && s != "k2=2; k=1" {
+		t.Errorf("Unexpected string: %s", s)
+	}
+}
+
+func TestConflictsFront(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	conflicts := m.ConflictsFront(map[string]int{
+		"a": 10,
+		"c": 2,
+		"b": 1,
+		"d": 4,
+	})
+	if l := len(conflicts); l != 3 {
+		t.Fatalf("Lengths not equal, want: %d, got: %d", 3, l)
+	}
+	for _, c := range conflicts {
+		switch c.Key {
+		case "a":
+			if c.Kind != ConflictKey || c.ExistingValue != 1 {
+				t.Errorf("Unexpected conflict: %+v", c)
+			}
+		case "c":
+			if c.Kind != ConflictValue || c.ExistingKey != "b" {
+				t.Errorf("Unexpected conflict: %+v", c)
+			}
+		case "b":
+			if c.Kind != ConflictBoth || c.ExistingValue != 2 || c.ExistingKey != "a" {
+				t.Errorf("Unexpected conflict: %+v", c)
+			}
+		default:
+			t.Errorf("Unexpected conflict: %+v", c)
+		}
+	}
+	if l := m.Len(); l != 2 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 2, l)
+	}
+
+	conflicts = m.ConflictsFront(map[string]int{"x": 5, "y": 5, "z": 6})
+	if l := len(conflicts); l != 2 {
+		t.Fatalf("Lengths not equal, want: %d, got: %d", 2, l)
+	}
+	for _, c := range conflicts {
+		other := map[string]string{"x": "y", "y": "x"}[c.Key]
+		if c.Kind != ConflictValue || c.Value != 5 || c.ExistingKey != other {
+			t.Errorf("Unexpected conflict: %+v", c)
+		}
+	}
+	if err := m.SetFrontValidated(map[string]int{"x": 5, "y": 5}, func(string, int) error { return nil }); err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+	if conflicts := m.ConflictsFront(map[string]int{"x": 5, "z": 6}); len(conflicts) != 0 {
+		t.Errorf("Unexpected conflicts: %+v", conflicts)
+	}
+}
+
+func TestSetFrontValidated(t *testing.T) {
+	m := New[string, int]()
+	errNegative := errors.New("negative value")
+	validate := func(f string, b int) error {
+		if b < 0 {
+			return errNegative
+		}
+		return nil
+	}
+	if err := m.SetFrontValidated(map[string]int{"a": 1, "b": -2, "c": 3}, validate); err != errNegative {
+		t.Errorf("Errors not equal, want: %v, got: %v", errNegative, err)
+	}
+	if l := m.Len(); l != 0 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 0, l)
+	}
+	if err := m.SetFrontValidated(map[string]int{"a": 1, "b": 1}, validate); err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+	if err := m.SetFrontValidated(map[string]int{"a": 1, "b": 2}, validate); err != nil {
+		t.Error(err)
+	}
+	if l := m.Len(); l != 2 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 2, l)
+	}
+}
+
+func TestGetOrSetFront(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1}))
+	if actual, loaded := m.GetOrSetFront("a", 2); !loaded || actual != 1 {
+		t.Errorf("Values not equal, want: %d, got: %d", 1, actual)
+	}
+	if actual, loaded := m.GetOrSetFront("b", 2); loaded || actual != 2 {
+		t.Errorf("Values not equal, want: %d, got: %d", 2, actual)
+	}
+	if actual, loaded := m.GetOrSetFront("c", 1); loaded || actual != 0 {
+		t.Errorf("Values not equal, want: %d, got: %d", 0, actual)
+	}
+	if _, ok := m.GetFront("c"); ok {
+		t.Error("Key should not be set")
+	}
+}
+
+func TestGetOrSetBack(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1}))
+	if actual, loaded := m.GetOrSetBack(1, "b"); !loaded || actual != "a" {
+		t.Errorf("Values not equal, want: %s, got: %s", "a", actual)
+	}
+	if actual, loaded := m.GetOrSetBack(2, "b"); loaded || actual != "b" {
+		t.Errorf("Values not equal, want: %s, got: %s", "b", actual)
+	}
+	if actual, loaded := m.GetOrSetBack(3, "a"); loaded || actual != "" {
+		t.Errorf("Values not equal, want: %q, got: %q", "", actual)
+	}
+	if _, ok := m.GetBack(3); ok {
+		t.Error("Key should not be set")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	fp := m.Fingerprint()
+	m.SetFront("c", 3)
+	if m.Fingerprint() == fp {
+		t.Error("Fingerprints should differ after adding a pair")
+	}
+	m.DeleteFront("c")
+	if got := m.Fingerprint(); got != fp {
+		t.Errorf("Fingerprints not equal, want: %d, got: %d", fp, got)
+	}
+	other := New(WithInitialMap(map[string]int{"b": 2, "a": 1}))
+	if got := other.Fingerprint(); got != fp {
+		t.Errorf("Fingerprints not equal, want: %d, got: %d", fp, got)
+	}
+	swapped := New(WithInitialMap(map[string]int{"a": 2, "b": 1}))
+	if swapped.Fingerprint() == fp {
+		t.Error("Fingerprints should differ for different contents")
+	}
+}
+
+func TestPeek(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	val, found, size := m.Peek("a")
+	if !found || val != 1 {
+		t.Errorf("Values not equal, want: %d, got: %d", 1, val)
+	}
+	if size != m.Len() {
+		t.Errorf("Sizes not equal, want: %d, got: %d", m.Len(), size)
+	}
+	if _, found, size := m.Peek("z"); found || size != 2 {
+		t.Errorf("Unexpected result: %v, %d", found, size)
+	}
+}
+
+func TestGetFrontOrdered(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "c": 3}))
+	res := m.GetFrontOrdered([]string{"c", "b", "a", "c"})
+	want := []Lookup[int]{{3, true}, {0, false}, {1, true}, {3, true}}
+	if len(res) != len(want) {
+		t.Fatalf("Lengths not equal, want: %d, got: %d", len(want), len(res))
+	}
+	for i := range want {
+		if res[i] != want[i] {
+			t.Errorf("Results not equal at %d, want: %+v, got: %+v", i, want[i], res[i])
+		}
+	}
+}
+
+// corrupt replaces the value of key in front map without updating back map
+func corrupt[T, U comparable](m *BiMap[T, U], key T, val U) {
+	m.front[key] = val
+}
+
+func TestRebuildFront(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	corrupt(m, "a", 10)
+	corrupt(m, "x", 99)
+	m.RebuildFront()
+	want := map[string]int{"a": 1, "b": 2}
+	front := m.Front()
+	if len(front) != len(want) {
+		t.Fatalf("Lengths not equal, want: %d, got: %d", len(want), len(front))
+	}
+	for k, v := range want {
+		if front[k] != v {
+			t.Errorf("Values not equal, want: %d, got: %d", v, front[k])
+		}
+	}
+}
+
+func TestRebuildBack(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	corrupt(m, "a", 10)
+	m.RebuildBack()
+	if key, _ := m.GetBack(10); key != "a" {
+		t.Errorf("Values not equal, want: %s, got: %s", "a", key)
+	}
+	if _, ok := m.GetBack(1); ok {
+		t.Error("Stale value should be removed")
+	}
+}
+
+func TestWithDefaultValue(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1}), WithDefaultValue[string](-1, true))
+	if val, ok := m.GetFront("a"); !ok || val != 1 {
+		t.Errorf("Values not equal, want: %d, got: %d", 1, val)
+	}
+	if val, ok := m.GetFront("b"); !ok || val != -1 {
+		t.Errorf("Values not equal, want: %d, got: %d", -1, val)
+	}
+	m = New(WithDefaultValue[string](-1, false))
+	val, ok := m.GetFront("b")
+	if ok {
+		t.Error("Key should not exist")
+	}
+	if val != -1 {
+		t.Errorf("Values not equal, want: %d, got: %d", -1, val)
+	}
+	if _, ok := m.GetBack(-1); ok {
+		t.Error("Default value should not be stored")
+	}
+}
+
+func TestExtend(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1}))
+	seq := func(yield func(string, int) bool) {
+		for _, p := range []Entry[string, int]{{"b", 2}, {"c", 3}, {"d", 1}, {"e", 5}} {
+			if !yield(p.Key, p.Value) {
+				return
+			}
+		}
+	}
+	added, err := m.Extend(seq)
+	if err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+	if added != 2 {
+		t.Errorf("Counts not equal, want: %d, got: %d", 2, added)
+	}
+	if ok, missing := m.AllPresentFront([]string{"a", "b", "c"}); !ok {
+		t.Errorf("Key %s should exist", missing)
+	}
+	if _, ok := m.GetFront("e"); ok {
+		t.Error("Pairs after the conflict should not be added")
+	}
+}
+
+func TestMinMaxBack(t *testing.T) {
+	m := New[string, int]()
+	less := func(a, b int) bool {
+		return a < b
+	}
+	if _, _, ok := m.MaxBack(less); ok {
+		t.Error("Should be empty")
+	}
+	m.SetFront("a", 5)
+	m.SetFront("b", -3)
+	m.SetFront("c", 12)
+	m.SetFront("d", 7)
+	if f, b, _ := m.MaxBack(less); f != "c" || b != 12 {
+		t.Errorf("Pairs not equal, want: c:12, got: %s:%d", f, b)
+	}
+	if f, b, _ := m.MinBack(less); f != "b" || b != -3 {
+		t.Errorf("Pairs not equal, want: b:-3, got: %s:%d", f, b)
+	}
+}
+
+func TestKeyDiff(t *testing.T) {
+	a := New(WithInitialMap(map[string]int{"a": 1, "b": 2, "c": 3}))
+	b := New(WithInitialMap(map[string]int{"b": 20, "c": 3, "d": 4, "e": 5}))
+	onlyHere, onlyThere := a.KeyDiff(b)
+	sort.Strings(onlyThere)
+	if len(onlyHere) != 1 || onlyHere[0] != "a" {
+		t.Errorf("Unexpected keys: %v", onlyHere)
+	}
+	if len(onlyThere) != 2 || onlyThere[0] != "d" || onlyThere[1] != "e" {
+		t.Errorf("Unexpected keys: %v", onlyThere)
+	}
+	c := New(WithInitialMap(map[string]int{"x": 1}))
+	onlyHere, onlyThere = a.KeyDiff(c)
+	if len(onlyHere) != 3 || len(onlyThere) != 1 {
+		t.Errorf("Lengths not equal, want: 3 and 1, got: %d and %d", len(onlyHere), len(onlyThere))
+	}
+	if onlyHere, onlyThere := a.KeyDiff(a); len(onlyHere) != 0 || len(onlyThere) != 0 {
+		t.Error("Should have no difference with itself")
+	}
+}
+
+func TestExchange(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	old, err := m.Exchange(map[string]int{"c": 1, "d": 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(old) != 2 || old["a"] != 1 || old["b"] != 2 {
+		t.Errorf("Unexpected old contents: %v", old)
+	}
+	if key, _ := m.GetBack(1); key != "c" {
+		t.Errorf("Values not equal, want: %s, got: %s", "c", key)
+	}
+	if _, ok := m.GetFront("a"); ok {
+		t.Error("Old pairs should be removed")
+	}
+	if _, err := m.Exchange(map[string]int{"x": 9, "y": 9}); err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+	if l := m.Len(); l != 2 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 2, l)
+	}
+	if _, ok := m.GetFront("x"); ok {
+		t.Error("Invalid pairs should not be set")
+	}
+}
+
+func TestSetFrontOrReport(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	if _, conflicted := m.SetFrontOrReport("c", 3); conflicted {
+		t.Error("New pair should not conflict")
+	}
+	if _, conflicted := m.SetFrontOrReport("a", 1); conflicted {
+		t.Error("Existing pair should not conflict")
+	}
+	if _, conflicted := m.SetFrontOrReport("a", 10); conflicted {
+		t.Error("Upsert should not conflict")
+	}
+	if _, ok := m.GetBack(1); ok {
+		t.Error("Old value should be removed")
+	}
+	key, conflicted := m.SetFrontOrReport("c", 2)
+	if !conflicted || key != "b" {
+		t.Errorf("Conflict keys not equal, want: %s, got: %s", "b", key)
+	}
+	if val, _ := m.GetFront("c"); val != 3 {
+		t.Errorf("Values not equal, want: %d, got: %d", 3, val)
+	}
+	if l := m.Len(); l != 3 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 3, l)
+	}
+}
+
+func TestOrphans(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	if frontOnly, backOnly := m.Orphans(); len(frontOnly) != 0 || len(backOnly) != 0 {
+		t.Errorf("Should have no orphans, got: %v and %v", frontOnly, backOnly)
+	}
+	corrupt(m, "x", 99)
+	m.back[50] = "y"
+	frontOnly, backOnly := m.Orphans()
+	if len(frontOnly) != 1 || frontOnly[0] != "x" {
+		t.Errorf("Unexpected front orphans: %v", frontOnly)
+	}
+	if len(backOnly) != 1 || backOnly[0] != 50 {
+		t.Errorf("Unexpected back orphans: %v", backOnly)
+	}
+}
+
+func TestMoveFront(t *testing.T) {
+	src := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	dst := New(WithInitialMap(map[string]int{"c": 2}))
+	if err := MoveFront(src, dst, "z"); err != ErrKeyNotExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyNotExists, err)
+	}
+	if err := MoveFront(src, dst, "b"); err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+	if src.Len() != 2 || dst.Len() != 1 {
+		t.Errorf("Maps should not be modified, got: %v and %v", src, dst)
+	}
+	if err := MoveFront(src, dst, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := src.GetFront("a"); ok {
+		t.Error("Pair should be removed from src")
+	}
+	if key, _ := dst.GetBack(1); key != "a" {
+		t.Errorf("Values not equal, want: %s, got: %s", "a", key)
+	}
+	if err := MoveFront(dst, dst, "a"); err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+}
+
+func TestSymmetricDiff(t *testing.T) {
+	a := New(WithInitialMap(map[string]int{"a": 1, "b": 2, "c": 3}))
+	b := New(WithInitialMap(map[string]int{"b": 2, "c": 30, "d": 4, "e": 1}))
+	d := a.SymmetricDiff(b)
+	want := map[string]int{"a": 1, "c": 3, "d": 4}
+	front := d.Front()
+	if len(front) != len(want) {
+		t.Fatalf("Unexpected result: %v", front)
+	}
+	for k, v := range want {
+		if front[k] != v {
+			t.Errorf("Values not equal, want: %d, got: %d", v, front[k])
+		}
+	}
+	c := New(WithInitialMap(map[string]int{"x": 10}))
+	if l := a.SymmetricDiff(c).Len(); l != 4 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 4, l)
+	}
+}
+
+func TestWithValidator(t *testing.T) {
+	errEmpty := errors.New("empty key")
+	m := New(WithValidator(func(f string, b int) error {
+		if f == "" {
+			return errEmpty
+		}
+		return nil
+	}))
+	if err := m.SetFront("", 1); err != errEmpty {
+		t.Errorf("Errors not equal, want: %v, got: %v", errEmpty, err)
+	}
+	if err := m.SetBack(1, ""); err != errEmpty {
+		t.Errorf("Errors not equal, want: %v, got: %v", errEmpty, err)
+	}
+	if err := m.SetFront("a", 1); err != nil {
+		t.Error(err)
+	}
+	if l := m.Len(); l != 1 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 1, l)
+	}
+}
+
+func TestWithValidatorReplacing(t *testing.T) {
+	errNegative := errors.New("negative value")
+	validated := func() *BiMap[string, int] {
+		return New(WithInitialMap(map[string]int{"a": 1}), WithValidator(func(f string, b int) error {
+			if b < 0 {
+				return errNegative
+			}
+			return nil
+		}))
+	}
+	m := validated()
+	if _, err := m.Exchange(map[string]int{"x": -1}); err != errNegative {
+		t.Errorf("Errors not equal, want: %v, got: %v", errNegative, err)
+	}
+	if err := m.UnmarshalJSONPairs([]byte(`[["x",-1]]`)); err != errNegative {
+		t.Errorf("Errors not equal, want: %v, got: %v", errNegative, err)
+	}
+	src := New(WithInitialMap(map[string]int{"x": -1}))
+	if err := MoveFront(src, m, "x"); err != errNegative {
+		t.Errorf("Errors not equal, want: %v, got: %v", errNegative, err)
+	}
+	if src.Len() != 1 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 1, src.Len())
+	}
+	if !sameContents(m, validated()) {
+		t.Errorf("Values not equal, want: %v, got: %v", validated(), m)
+	}
+}
+
+func TestWithValidatorNoError(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1}), WithValidator(func(f string, b int) error {
+		if b < 0 {
+			return errors.New("negative value")
+		}
+		return nil
+	}))
+	if v, loaded := m.GetOrSetFront("b", -2); loaded || v != 0 {
+		t.Errorf("Values not equal, want: %v, got: %v", 0, v)
+	}
+	if k, loaded := m.GetOrSetBack(-3, "c"); loaded || k != "" {
+		t.Errorf("Values not equal, want: %q, got: %q", "", k)
+	}
+	if k, conflicted := m.SetFrontOrReport("d", -4); !conflicted || k != "" {
+		t.Errorf("Values not equal, want: %v, got: %v", true, conflicted)
+	}
+	if d := m.SetFrontDisplacing("a", -5); d != nil {
+		t.Errorf("Values not equal, want: %v, got: %v", nil, d)
+	}
+	if !sameContents(m, New(WithInitialMap(map[string]int{"a": 1}))) {
+		t.Errorf("Values not equal, want: %v, got: %v", map[string]int{"a": 1}, m.Front())
+	}
+	if v, _ := m.GetOrSetFront("b", 2); v != 2 {
+		t.Errorf("Values not equal, want: %v, got: %v", 2, v)
+	}
+
+	short := New(WithMaxStringLen[int](3))
+	if d := short.SetFrontDisplacing("toolongkey", 1); d != nil || short.Len() != 0 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 0, short.Len())
+	}
+}
+
+func TestSample(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2, "c": 3}))
+	for n, want := range map[int]int{-1: 0, 0: 0, 2: 2, 3: 3, 10: 3} {
+		pairs := m.Sample(n)
+		if len(pairs) != want {
+			t.Errorf("Lengths not equal for n = %d, want: %d, got: %d", n, want, len(pairs))
+		}
+		for _, p := range pairs {
+			if val, _ := m.GetFront(p.Front); val != p.Back {
+				t.Errorf("Values not equal, want: %d, got: %d", val, p.Back)
+			}
+		}
+	}
+}
+
+func TestWithBackReadTransform(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"alice": 1}), WithBackReadTransform[string, int](strings.ToUpper))
+	if val, _ := m.GetBack(1); val != "ALICE" {
+		t.Errorf("Values not equal, want: %s, got: %s", "ALICE", val)
+	}
+	if val := m.Back()[1]; val != "alice" {
+		t.Errorf("Values not equal, want: %s, got: %s", "alice", val)
+	}
+	if _, ok := m.GetFront("alice"); !ok {
+		t.Error("Stored key should not be transformed")
+	}
+}
+
+func TestApproxSizeBytes(t *testing.T) {
+	small := newBenchMap(100).ApproxSizeBytes()
+	large := newBenchMap(10000).ApproxSizeBytes()
+	if small <= 0 {
+		t.Fatalf("Size should be positive, got: %d", small)
+	}
+	if ratio := float64(large) / float64(small); ratio < 50 || ratio > 200 {
+		t.Errorf("Size should scale with entry count, got ratio: %f", ratio)
+	}
+}
+
+func TestMergeWith(t *testing.T) {
+	sum := func(a, b int) int {
+		return a + b
+	}
+	dst := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	src := New(WithInitialMap(map[string]int{"b": 10, "c": 5}))
+	if err := MergeWith(dst, src, sum); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"a": 1, "b": 12, "c": 5}
+	front := dst.Front()
+	if len(front) != len(want) {
+		t.Fatalf("Unexpected result: %v", front)
+	}
+	for k, v := range want {
+		if front[k] != v {
+			t.Errorf("Values not equal, want: %d, got: %d", v, front[k])
+		}
+		if key, _ := dst.GetBack(v); key != k {
+			t.Errorf("Values not equal, want: %s, got: %s", k, key)
+		}
+	}
+	collide := New(WithInitialMap(map[string]int{"a": 4}))
+	if err := MergeWith(dst, collide, sum); err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+	if val, _ := dst.GetFront("a"); val != 1 {
+		t.Errorf("Values not equal, want: %d, got: %d", 1, val)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"apple": 1, "avocado": 2, "banana": 3, "cherry": 4, "blueberry": 5}))
+	groups := GroupBy(m, func(f string, b int) byte {
+		return f[0]
+	})
+	for k, want := range map[byte]int{'a': 2, 'b': 2, 'c': 1} {
+		if got := len(groups[k]); got != want {
+			t.Errorf("Counts not equal for %c, want: %d, got: %d", k, want, got)
+		}
+	}
+	if len(groups) != 3 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 3, len(groups))
+	}
+}
+
+func TestAssertFront(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1}))
+	if err := m.AssertFront("a", 1); err != nil {
+		t.Error(err)
+	}
+	err := m.AssertFront("b", 2)
+	if !errors.Is(err, ErrKeyNotExists) {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyNotExists, err)
+	}
+	err = m.AssertFront("a", 2)
+	if !errors.Is(err, ErrValueMismatch) {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrValueMismatch, err)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "want value 2") || !strings.Contains(msg, "got 1") {
+		t.Errorf("Error should mention both values, got: %s", msg)
+	}
+}
+
+func TestInvertFiltered(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}))
+	inv := InvertFiltered(m, func(f string, b int) bool {
+		return b%2 == 0
+	})
+	front := inv.Front()
+	if len(front) != 2 || front[2] != "b" || front[4] != "d" {
+		t.Errorf("Unexpected result: %v", front)
+	}
+	if key, _ := inv.GetBack("d"); key != 4 {
+		t.Errorf("Values not equal, want: %d, got: %d", 4, key)
+	}
+}
+
+func TestKeysWhere(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 20, "c": 3, "d": 40}))
+	keys := m.KeysWhere(func(b int) bool {
+		return b >= 10
+	})
+	sort.Strings(keys)
+	if len(keys) != 2 || keys[0] != "b" || keys[1] != "d" {
+		t.Errorf("Unexpected keys: %v", keys)
+	}
+}
+
+func TestSetFrontDisplacing(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	if d := m.SetFrontDisplacing("c", 3); len(d) != 0 {
+		t.Errorf("Unexpected displaced pairs: %v", d)
+	}
+	d := m.SetFrontDisplacing("c", 4)
+	if len(d) != 1 || d[0] != (Pair[string, int]{"c", 3}) {
+		t.Errorf("Unexpected displaced pairs: %v", d)
+	}
+	d = m.SetFrontDisplacing("a", 2)
+	if len(d) != 2 || d[0] != (Pair[string, int]{"a", 1}) || d[1] != (Pair[string, int]{"b", 2}) {
+		t.Errorf("Unexpected displaced pairs: %v", d)
+	}
+	front := m.Front()
+	if len(front) != 2 || front["a"] != 2 || front["c"] != 4 {
+		t.Errorf("Unexpected contents: %v", front)
+	}
+	if d := m.SetFrontDisplacing("a", 2); len(d) != 0 {
+		t.Errorf("Unexpected displaced pairs: %v", d)
+	}
+}
+
+func TestAreInverses(t *testing.T) {
+	a := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	b := New(WithInitialMap(map[int]string{1: "a", 2: "b"}))
+	if !AreInverses(a, b) {
+		t.Error("Should be inverses")
+	}
+	b.DeleteFront(2)
+	b.SetFront(2, "c")
+	if AreInverses(a, b) {
+		t.Error("Should not be inverses")
+	}
+	b.DeleteFront(2)
+	if AreInverses(a, b) {
+		t.Error("Should not be inverses")
+	}
+}
+
+func TestCompose(t *testing.T) {
+	a := New(WithInitialMap(map[string]int{"one": 1, "two": 2, "three": 3}))
+	b := New(WithInitialMap(map[int]rune{1: 'a', 2: 'b', 3: 'c'}))
+	c, err := Compose(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Len() != 3 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 3, c.Len())
+	}
+	if val, _ := c.GetFront("two"); val != 'b' {
+		t.Errorf("Values not equal, want: %c, got: %c", 'b', val)
+	}
+	b.DeleteFront(3)
+	c, err = Compose(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.GetFront("three"); ok {
+		t.Error("Unmapped key should be left out")
+	}
+	if key, _ := c.GetBack('a'); key != "one" {
+		t.Errorf("Values not equal, want: %s, got: %s", "one", key)
+	}
+}
+
+func TestValueHistogram(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 5, "b": 15, "c": 12, "d": 27, "e": 3}))
+	hist := ValueHistogram(m, func(b int) int {
+		return b / 10 * 10
+	})
+	want := map[int]int{0: 2, 10: 2, 20: 1}
+	if len(hist) != len(want) {
+		t.Fatalf("Unexpected histogram: %v", hist)
+	}
+	for k, v := range want {
+		if hist[k] != v {
+			t.Errorf("Counts not equal for %d, want: %d, got: %d", k, v, hist[k])
+		}
+	}
+}
+
+func TestInitOnce(t *testing.T) {
+	m := New[string, int]()
+	var calls atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := m.InitOnce(func() map[string]int {
+				calls.Add(1)
+				return map[string]int{"a": 1, "b": 2}
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Calls not equal, want: %d, got: %d", 1, n)
+	}
+	if l := m.Len(); l != 2 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 2, l)
+	}
+
+	m = New[string, int]()
+	err := m.InitOnce(func() map[string]int {
+		return map[string]int{"a": 1, "b": 1}
+	})
+	if err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+	if l := m.Len(); l != 0 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 0, l)
+	}
+}
+
+func TestCountBy(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5}))
+	matched, unmatched := m.CountBy(func(f string, b int) bool {
+		return b%2 == 1
+	})
+	if matched != 3 || unmatched != 2 {
+		t.Errorf("Counts not equal, want: 3 and 2, got: %d and %d", matched, unmatched)
+	}
+	if matched+unmatched != m.Len() {
+		t.Errorf("Counts should add up to %d", m.Len())
+	}
+}
+
+func TestCountBackFunc(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5}))
+	n := m.CountBackFunc(func(b int, f string) bool {
+		return b > 2
+	})
+	if n != 3 {
+		t.Errorf("Values not equal, want: %v, got: %v", 3, n)
+	}
+	if n := m.CountBackFunc(func(b int, f string) bool { return false }); n != 0 {
+		t.Errorf("Values not equal, want: %v, got: %v", 0, n)
+	}
+}
+
+func TestGrowOnly(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}), WithGrowOnly[string, int]())
+	m.DeleteFront("a")
+	m.DeleteBack(2)
+	if m.DeleteFrontReport("a") {
+		t.Errorf("Values not equal, want: %v, got: %v", false, true)
+	}
+	m.Clear()
+	if n := m.ForRemovable(func(string, int) bool { return true }); n != 0 {
+		t.Errorf("Values not equal, want: %v, got: %v", 0, n)
+	}
+	if m.Len() != 2 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 2, m.Len())
+	}
+	if err := m.SetFront("c", 3); err != nil {
+		t.Errorf("Errors not equal, want: %v, got: %v", nil, err)
+	}
+	if v, ok := m.GetFront("c"); !ok || v != 3 {
+		t.Errorf("Values not equal, want: %v, got: %v", 3, v)
+	}
+	if m.Len() != 3 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 3, m.Len())
+	}
+	if v, _ := m.UpdateFront("c", func(int) int { return 4 }); v != 4 {
+		t.Errorf("Values not equal, want: %v, got: %v", 4, v)
+	}
+	if d := m.SetFrontDisplacing("d", 4); d != nil {
+		t.Errorf("Values not equal, want: %v, got: %v", nil, d)
+	}
+}
+
+func TestGrowOnlyRemovingPaths(t *testing.T) {
+	pairs := map[string]int{"a": 1, "b": 2}
+	subset := map[string]int{"a": 1}
+	superset := map[string]int{"a": 1, "b": 2, "c": 3}
+	tests := []struct {
+		name    string
+		fn      func(m *BiMap[string, int]) error
+		wantErr error
+	}{
+		{"Tx", func(m *BiMap[string, int]) error {
+			return m.Batch(func(tx *Tx[string, int]) error {
+				tx.DeleteFront("a")
+				tx.DeleteBack(2)
+				return nil
+			})
+		}, nil},
+		{"Exchange", func(m *BiMap[string, int]) error {
+			_, err := m.Exchange(subset)
+			return err
+		}, ErrGrowOnly},
+		{"ApplyPatch", func(m *BiMap[string, int]) error {
+			return m.ApplyPatch([]Op[string, int]{{Kind: OpDelete, Front: "a"}})
+		}, ErrGrowOnly},
+		{"MoveFront", func(m *BiMap[string, int]) error {
+			return MoveFront(m, New[string, int](), "a")
+		}, ErrGrowOnly},
+		{"Reload", func(m *BiMap[string, int]) error {
+			return m.Reload(maps.All(subset))
+		}, ErrGrowOnly},
+		{"SwapContents", func(m *BiMap[string, int]) error {
+			_, err := m.SwapContents(subset)
+			return err
+		}, ErrGrowOnly},
+		{"UnmarshalBinary", func(m *BiMap[string, int]) error {
+			data, _ := New(WithInitialMap(subset)).MarshalBinary()
+			return m.UnmarshalBinary(data)
+		}, ErrGrowOnly},
+		{"UnmarshalJSONPairs", func(m *BiMap[string, int]) error {
+			return m.UnmarshalJSONPairs([]byte(`[["a",1]]`))
+		}, ErrGrowOnly},
+		{"ReadJSONL", func(m *BiMap[string, int]) error {
+			return m.ReadJSONL(strings.NewReader(`{"front":"a","back":1}`))
+		}, ErrGrowOnly},
+		{"RenameFrontKeys", func(m *BiMap[string, int]) error {
+			return m.RenameFrontKeys(map[string]string{"a": "z"})
+		}, ErrGrowOnly},
+	}
+	for _, tt := range tests {
+		m := New(WithInitialMap(pairs), WithGrowOnly[string, int]())
+		if err := tt.fn(m); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: Errors not equal, want: %v, got: %v", tt.name, tt.wantErr, err)
+		}
+		if !sameContents(m, New(WithInitialMap(pairs))) {
+			t.Errorf("%s: Values not equal, want: %v, got: %v", tt.name, pairs, m)
+		}
+	}
+
+	m := New(WithInitialMap(pairs), WithGrowOnly[string, int]())
+	if _, err := m.Exchange(superset); err != nil {
+		t.Errorf("Errors not equal, want: %v, got: %v", nil, err)
+	}
+	if err := m.RenameFrontKeys(map[string]string{"a": "b", "b": "a"}); err != nil {
+		t.Errorf("Errors not equal, want: %v, got: %v", nil, err)
+	}
+	if v, _ := m.GetFront("a"); v != 2 {
+		t.Errorf("Values not equal, want: %v, got: %v", 2, v)
+	}
+}
+
+func TestValidateNoZeroValues(t *testing.T) {
+	m := New(WithInitialMap(map[string]string{"host": "localhost", "port": "8080"}))
+	if err := m.ValidateNoZeroValues(); err != nil {
+		t.Errorf("Errors not equal, want: %v, got: %v", nil, err)
+	}
+	m.SetFront("user", "")
+	err := m.ValidateNoZeroValues()
+	if !errors.Is(err, ErrZeroValue) {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrZeroValue, err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "user") {
+		t.Errorf("Error %q should name key %q", err, "user")
+	}
+}
+
+func TestMutateRaw(t *testing.T) {
+	var events []Event[string, int]
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2, "c": 3}), WithObserver(func(e Event[string, int]) {
+		events = append(events, e)
+	}))
+	m.MutateRaw(func(front map[string]int, back map[int]string) {
+		for k, v := range front {
+			delete(back, v)
+			front[k] = v * 10
+		}
+		for k, v := range front {
+			back[v] = k
+		}
+	})
+	if err := m.CheckInvariant(); err != nil {
+		t.Errorf("Errors not equal, want: %v, got: %v", nil, err)
+	}
+	if key, ok := m.GetBack(20); !ok || key != "b" {
+		t.Errorf("Values not equal, want: %v, got: %v", "b", key)
+	}
+	if m.Len() != 3 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 3, m.Len())
+	}
+	if len(events) != 6 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 6, len(events))
+	}
+	if f, b, ok := m.LastModified(); !ok || m.GetFrontOrZero(f) != b || b < 10 {
+		t.Errorf("Unexpected last modified pair: %v %v %v", f, b, ok)
+	}
+
+	ordered := New(WithInsertionOrder[string, int]())
+	ordered.SetFront("a", 1)
+	ordered.SetFront("b", 2)
+	ordered.MutateRaw(func(front map[string]int, back map[int]string) {
+		delete(front, "a")
+		delete(back, 1)
+	})
+	if f, b, ok := ordered.Oldest(); !ok || f != "b" || b != 2 {
+		t.Errorf("Values not equal, want: %v, got: %v", "b 2 true", []any{f, b, ok})
+	}
+}
+
+func TestMutateRawPanics(t *testing.T) {
+	mustPanic := func(m *BiMap[string, int], want error, fn func(front map[string]int, back map[int]string)) {
+		t.Helper()
+		defer func() {
+			err, _ := recover().(error)
+			if !errors.Is(err, want) {
+				t.Errorf("Errors not equal, want: %v, got: %v", want, err)
+			}
+			if err := m.CheckInvariant(); err != nil {
+				t.Errorf("Errors not equal, want: %v, got: %v", nil, err)
+			}
+			if !sameContents(m, New(WithInitialMap(map[string]int{"a": 1, "b": 2}))) {
+				t.Error("Previous pairs should be restored")
+			}
+		}()
+		m.MutateRaw(fn)
+		t.Error("MutateRaw should panic")
+	}
+	pairs := WithInitialMap(map[string]int{"a": 1, "b": 2})
+	mustPanic(New(pairs), ErrInvariant, func(front map[string]int, back map[int]string) {
+		front["d"] = 40
+	})
+	mustPanic(New(pairs, WithGrowOnly[string, int]()), ErrGrowOnly, func(front map[string]int, back map[int]string) {
+		delete(front, "a")
+		delete(back, 1)
+	})
+}
+
+func TestChainLength(t *testing.T) {
+	m := New(WithInitialMap(map[string]string{"a": "b", "b": "c", "c": "d", "x": "y", "y": "z", "z": "x"}))
+	tests := []struct {
+		start string
+		want  int
+	}{
+		{"a", 3}, {"b", 2}, {"c", 1}, {"d", 0}, {"x", 3}, {"z", 3},
+	}
+	for _, tt := range tests {
+		if n := ChainLength(m, tt.start); n != tt.want {
+			t.Errorf("Values not equal, want: %v, got: %v", tt.want, n)
+		}
+	}
+	self := New(WithInitialMap(map[int]int{1: 1}))
+	if n := ChainLength(self, 1); n != 1 {
+		t.Errorf("Values not equal, want: %v, got: %v", 1, n)
+	}
+}
+
+func TestReload(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1}))
+	seq := func(pairs ...Entry[string, int]) func(yield func(string, int) bool) {
+		return func(yield func(string, int) bool) {
+			for _, p := range pairs {
+				if !yield(p.Key, p.Value) {
+					return
+				}
+			}
+		}
+	}
+	if err := m.Reload(seq(Entry[string, int]{"b", 2}, Entry[string, int]{"c", 3})); err != nil {
+		t.Fatal(err)
+	}
+	if front := m.Front(); len(front) != 2 || front["b"] != 2 || front["c"] != 3 {
+		t.Errorf("Unexpected contents: %v", front)
+	}
+	err := m.Reload(seq(Entry[string, int]{"x", 1}, Entry[string, int]{"y", 1}))
+	if err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+	if front := m.Front(); len(front) != 2 || front["b"] != 2 || front["c"] != 3 {
+		t.Errorf("Old contents should remain, got: %v", front)
+	}
+}
+
+func TestTypeInfo(t *testing.T) {
+	f, b := New[string, int]().TypeInfo()
+	if f != "string" || b != "int" {
+		t.Errorf("Types not equal, want: string and int, got: %s and %s", f, b)
+	}
+	f, b = New[any, user]().TypeInfo()
+	if f != "interface {}" || b != "bimap.user" {
+		t.Errorf("Types not equal, want: interface {} and bimap.user, got: %s and %s", f, b)
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 11, "b": 12, "c": 21, "d": 15, "e": 30}))
+	sameTens := func(a, b int) bool {
+		return a/10 == b/10
+	}
+	keys := m.Neighbors("a", sameTens)
+	sort.Strings(keys)
+	if len(keys) != 2 || keys[0] != "b" || keys[1] != "d" {
+		t.Errorf("Unexpected neighbors: %v", keys)
+	}
+	if keys := m.Neighbors("e", sameTens); len(keys) != 0 {
+		t.Errorf("Unexpected neighbors: %v", keys)
+	}
+	if keys := m.Neighbors("z", sameTens); keys != nil {
+		t.Errorf("Unexpected neighbors: %v", keys)
+	}
+}
+
+func TestGoLiteral(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"b": 2, "a\"q": 1, "c": 3}))
+	want := `map[string]int{"a\"q": 1, "b": 2, "c": 3}`
+	got := m.GoLiteral()
+	if got != want {
+		t.Errorf("Literals not equal, want: %s, got: %s", want, got)
+	}
+	if _, err := parser.ParseExpr(got); err != nil {
+		t.Errorf("Literal should parse: %v", err)
+	}
+	if got := New[int, string]().GoLiteral(); got != "map[int]string{}" {
+		t.Errorf("Literals not equal, want: %s, got: %s", "map[int]string{}", got)
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	top := New(WithInitialMap(map[string]int{"a": 10, "b": 2}))
+	base := New(WithInitialMap(map[string]int{"a": 1, "c": 3, "d": 10}))
+	res := top.Overlay(base)
+	want := map[string]int{"a": 10, "b": 2, "c": 3}
+	front := res.Front()
+	if len(front) != len(want) {
+		t.Fatalf("Unexpected result: %v", front)
+	}
+	for k, v := range want {
+		if front[k] != v {
+			t.Errorf("Values not equal, want: %d, got: %d", v, front[k])
+		}
+	}
+	if top.Len() != 2 || base.Len() != 3 {
+		t.Error("Inputs should be untouched")
+	}
+}
+
+func TestNoKeyValueOverlap(t *testing.T) {
+	m := New(WithInitialMap(map[string]string{"a": "x", "b": "y"}))
+	if overlap := NoKeyValueOverlap(m); len(overlap) != 0 {
+		t.Errorf("Unexpected overlap: %v", overlap)
+	}
+	m.SetFront("x", "z")
+	m.SetFront("c", "c")
+	overlap := NoKeyValueOverlap(m)
+	sort.Strings(overlap)
+	if len(overlap) != 2 || overlap[0] != "c" || overlap[1] != "x" {
+		t.Errorf("Unexpected overlap: %v", overlap)
+	}
+}
+
+func TestRenameFrontKeys(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2, "c": 3}))
+	if err := m.RenameFrontKeys(map[string]string{"a": "b", "b": "a", "c": "d"}); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"a": 2, "b": 1, "d": 3}
+	front := m.Front()
+	if len(front) != len(want) {
+		t.Fatalf("Unexpected result: %v", front)
+	}
+	for k, v := range want {
+		if front[k] != v {
+			t.Errorf("Values not equal, want: %d, got: %d", v, front[k])
+		}
+		if key, _ := m.GetBack(v); key != k {
+			t.Errorf("Values not equal, want: %s, got: %s", k, key)
+		}
+	}
+	for _, mapping := range []map[string]string{
+		{"a": "x", "b": "d"},
+		{"a": "x", "b": "x"},
+		{"z": "y"},
+	} {
+		if err := m.RenameFrontKeys(mapping); err == nil {
+			t.Errorf("Rename should fail: %v", mapping)
+		}
+		if front := m.Front(); len(front) != len(want) || front["a"] != 2 || front["b"] != 1 || front["d"] != 3 {
+			t.Errorf("Map should not be modified, got: %v", front)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	a := New(WithInitialMap(map[string]int{"a": 1, "b": 2, "c": 3}))
+	if s := a.Similarity(New(WithInitialMap(map[string]int{"a": 1, "b": 2, "c": 3}))); s != 1 {
+		t.Errorf("Similarities not equal, want: %f, got: %f", 1.0, s)
+	}
+	if s := a.Similarity(New(WithInitialMap(map[string]int{"x": 1}))); s != 0 {
+		t.Errorf("Similarities not equal, want: %f, got: %f", 0.0, s)
+	}
+	if s := a.Similarity(New(WithInitialMap(map[string]int{"a": 1, "b": 20, "d": 4}))); s != 0.2 {
+		t.Errorf("Similarities not equal, want: %f, got: %f", 0.2, s)
+	}
+	if s := a.Similarity(a); s != 1 {
+		t.Errorf("Similarities not equal, want: %f, got: %f", 1.0, s)
+	}
+}
@@ -0,0 +1,406 @@
+package bimap
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"slices"
+)
+
+var (
+	ErrTrailingData    = errors.New("trailing data")
+	ErrUnsupportedType = errors.New("unsupported type")
+)
+
+var (
+	binaryMarshalerType   = reflect.TypeFor[encoding.BinaryMarshaler]()
+	binaryUnmarshalerType = reflect.TypeFor[encoding.BinaryUnmarshaler]()
+)
+
+// checkEncodable returns an error wrapping ErrUnsupportedType unless values of V survive appendValue and readValue.
+// Supported are booleans, numbers and strings, arrays and structs with only exported fields of supported types,
+// and types that implement encoding.BinaryMarshaler with a pointer that implements encoding.BinaryUnmarshaler.
+// Interfaces, pointers and channels are rejected since their values cannot be restored by value
+func checkEncodable[V any]() error {
+	return checkType(reflect.TypeFor[V]())
+}
+
+// checkType is checkEncodable for a reflect.Type
+func checkType(t reflect.Type) error {
+	if t.Implements(binaryMarshalerType) && reflect.PointerTo(t).Implements(binaryUnmarshalerType) {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return nil
+	case reflect.Array:
+		return checkType(t.Elem())
+	case reflect.Struct:
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if f.Name == "_" {
+				continue
+			}
+			if !f.IsExported() {
+				return fmt.Errorf("%w: %v has unexported field %s", ErrUnsupportedType, t, f.Name)
+			}
+			if err := checkType(f.Type); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: %v", ErrUnsupportedType, t)
+}
+
+// appendValue appends the length-prefixed encoding of v to buf, strings are stored as raw bytes
+// and other types with the fixed layout of encodeValue so the encoding only depends on the value
+func appendValue[V any](buf []byte, v V) ([]byte, error) {
+	var data []byte
+	if s, ok := any(v).(string); ok {
+		data = []byte(s)
+	} else {
+		var err error
+		if data, err = encodeValue(nil, reflect.ValueOf(&v).Elem()); err != nil {
+			return nil, err
+		}
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(data)))
+	return append(buf, data...), nil
+}
+
+// readValue reads a value written by appendValue
+func readValue[V any](r *bufio.Reader) (V, error) {
+	var v V
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return v, err
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return v, err
+	}
+	if _, ok := any(v).(string); ok {
+		return any(string(data)).(V), nil
+	}
+	rest, err := decodeValue(data, reflect.ValueOf(&v).Elem())
+	if err == nil && len(rest) != 0 {
+		err = ErrTrailingData
+	}
+	return v, err
+}
+
+// intWidth returns the number of bytes used for integers of the kind, int, uint and uintptr
+// always use 8 so the encoding does not depend on the platform
+func intWidth(k reflect.Kind) int {
+	switch k {
+	case reflect.Int8, reflect.Uint8:
+		return 1
+	case reflect.Int16, reflect.Uint16:
+		return 2
+	case reflect.Int32, reflect.Uint32, reflect.Float32, reflect.Complex64:
+		return 4
+	}
+	return 8
+}
+
+// appendUint appends the lowest width bytes of u in big-endian order
+func appendUint(buf []byte, u uint64, width int) []byte {
+	for i := width - 1; i >= 0; i-- {
+		buf = append(buf, byte(u>>(8*i)))
+	}
+	return buf
+}
+
+// appendFloat appends the bits of f with the given width, negative zero is stored as zero since they are equal keys
+func appendFloat(buf []byte, f float64, width int) []byte {
+	if f == 0 {
+		f = 0
+	}
+	if width == 4 {
+		return appendUint(buf, uint64(math.Float32bits(float32(f))), 4)
+	}
+	return appendUint(buf, math.Float64bits(f), 8)
+}
+
+// encodeValue appends v to buf, numbers have a fixed width, strings and the output of MarshalBinary
+// are length-prefixed, and arrays and structs are encoded element by element
+func encodeValue(buf []byte, v reflect.Value) ([]byte, error) {
+	t := v.Type()
+	if t.Implements(binaryMarshalerType) && reflect.PointerTo(t).Implements(binaryUnmarshalerType) {
+		data, err := v.Interface().(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		buf = binary.AppendUvarint(buf, uint64(len(data)))
+		return append(buf, data...), nil
+	}
+	switch k := t.Kind(); k {
+	case reflect.Bool:
+		if v.Bool() {
+			return append(buf, 1), nil
+		}
+		return append(buf, 0), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return appendUint(buf, uint64(v.Int()), intWidth(k)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return appendUint(buf, v.Uint(), intWidth(k)), nil
+	case reflect.Float32, reflect.Float64:
+		return appendFloat(buf, v.Float(), intWidth(k)), nil
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		buf = appendFloat(buf, real(c), intWidth(k))
+		return appendFloat(buf, imag(c), intWidth(k)), nil
+	case reflect.String:
+		buf = binary.AppendUvarint(buf, uint64(v.Len()))
+		return append(buf, v.String()...), nil
+	case reflect.Array:
+		var err error
+		for i := range v.Len() {
+			if buf, err = encodeValue(buf, v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case reflect.Struct:
+		var err error
+		for i := range v.NumField() {
+			if t.Field(i).Name == "_" {
+				continue
+			}
+			if buf, err = encodeValue(buf, v.Field(i)); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	}
+	return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, t)
+}
+
+// takeUint reads an integer of the given width written by appendUint and returns the rest of data
+func takeUint(data []byte, width int) (uint64, []byte, error) {
+	if len(data) < width {
+		return 0, nil, io.ErrUnexpectedEOF
+	}
+	var u uint64
+	for _, b := range data[:width] {
+		u = u<<8 | uint64(b)
+	}
+	return u, data[width:], nil
+}
+
+// takeFloat reads a float of the given width written by appendFloat and returns the rest of data
+func takeFloat(data []byte, width int) (float64, []byte, error) {
+	u, rest, err := takeUint(data, width)
+	if width == 4 {
+		return float64(math.Float32frombits(uint32(u))), rest, err
+	}
+	return math.Float64frombits(u), rest, err
+}
+
+// takeBytes reads a length-prefixed byte slice and returns the rest of data
+func takeBytes(data []byte) ([]byte, []byte, error) {
+	n, size := binary.Uvarint(data)
+	if size <= 0 || uint64(len(data)-size) < n {
+		return nil, nil, io.ErrUnexpectedEOF
+	}
+	data = data[size:]
+	return data[:n], data[n:], nil
+}
+
+// decodeValue sets v, which must be settable, from the start of data written by encodeValue and returns the rest
+func decodeValue(data []byte, v reflect.Value) ([]byte, error) {
+	t := v.Type()
+	if t.Implements(binaryMarshalerType) && reflect.PointerTo(t).Implements(binaryUnmarshalerType) {
+		b, rest, err := takeBytes(data)
+		if err != nil {
+			return nil, err
+		}
+		return rest, v.Addr().Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(b)
+	}
+	switch k := t.Kind(); k {
+	case reflect.Bool:
+		u, rest, err := takeUint(data, 1)
+		v.SetBool(u != 0)
+		return rest, err
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		w := intWidth(k)
+		u, rest, err := takeUint(data, w)
+		// shift the sign bit of the stored width into place
+		shift := 64 - 8*w
+		v.SetInt(int64(u<<shift) >> shift)
+		return rest, err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, rest, err := takeUint(data, intWidth(k))
+		v.SetUint(u)
+		return rest, err
+	case reflect.Float32, reflect.Float64:
+		f, rest, err := takeFloat(data, intWidth(k))
+		v.SetFloat(f)
+		return rest, err
+	case reflect.Complex64, reflect.Complex128:
+		re, rest, err := takeFloat(data, intWidth(k))
+		if err != nil {
+			return nil, err
+		}
+		im, rest, err := takeFloat(rest, intWidth(k))
+		v.SetComplex(complex(re, im))
+		return rest, err
+	case reflect.String:
+		b, rest, err := takeBytes(data)
+		v.SetString(string(b))
+		return rest, err
+	case reflect.Array:
+		var err error
+		for i := range v.Len() {
+			if data, err = decodeValue(data, v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return data, nil
+	case reflect.Struct:
+		var err error
+		for i := range v.NumField() {
+			if t.Field(i).Name == "_" {
+				continue
+			}
+			if data, err = decodeValue(data, v.Field(i)); err != nil {
+				return nil, err
+			}
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, t)
+}
+
+// EncodeKeys writes the number of keys in front map followed by each key, all length-prefixed,
+// it returns an error wrapping ErrUnsupportedType for key types that checkEncodable rejects
+func (m *BiMap[T, U]) EncodeKeys(w io.Writer) error {
+	if err := checkEncodable[T](); err != nil {
+		return err
+	}
+	m.rwLock.RLock()
+	buf := binary.AppendUvarint(nil, uint64(len(m.front)))
+	var err error
+	for f := range m.front {
+		if buf, err = appendValue(buf, f); err != nil {
+			break
+		}
+	}
+	m.rwLock.RUnlock()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf)
+	return err
+}
+
+// DecodeKeys reads the keys written by EncodeKeys
+func DecodeKeys[T comparable](r io.Reader) ([]T, error) {
+	if err := checkEncodable[T](); err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(r)
+	n, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]T, 0, n)
+	for i := uint64(0); i < n; i++ {
+		k, err := readValue[T](br)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, it writes the number of pairs followed by each pair
+// as its length-prefixed key and value. Pairs are sorted by their encoding so equal contents always
+// produce identical bytes. It returns an error wrapping ErrUnsupportedType for key or value types that
+// checkEncodable rejects, such as interfaces, pointers and structs with unexported fields
+func (m *BiMap[T, U]) MarshalBinary() ([]byte, error) {
+	if err := checkEncodable[T](); err != nil {
+		return nil, err
+	}
+	if err := checkEncodable[U](); err != nil {
+		return nil, err
+	}
+	m.rwLock.RLock()
+	pairs := make([][]byte, 0, len(m.front))
+	size := 0
+	for f, b := range m.front {
+		p, err := appendValue(nil, f)
+		if err == nil {
+			p, err = appendValue(p, b)
+		}
+		if err != nil {
+			m.rwLock.RUnlock()
+			return nil, err
+		}
+		pairs = append(pairs, p)
+		size += len(p)
+	}
+	m.rwLock.RUnlock()
+	slices.SortFunc(pairs, bytes.Compare)
+	buf := make([]byte, 0, binary.MaxVarintLen64+size)
+	buf = binary.AppendUvarint(buf, uint64(len(pairs)))
+	for _, p := range pairs {
+		buf = append(buf, p...)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, it replaces the contents with the pairs written by
+// MarshalBinary and will return an error without modification if either key or value repeats
+func (m *BiMap[T, U]) UnmarshalBinary(data []byte) error {
+	if err := checkEncodable[T](); err != nil {
+		return err
+	}
+	if err := checkEncodable[U](); err != nil {
+		return err
+	}
+	br := bufio.NewReader(bytes.NewReader(data))
+	n, err := binary.ReadUvarint(br)
+	if err != nil {
+		return err
+	}
+	tmp := New[T, U]()
+	for i := uint64(0); i < n; i++ {
+		f, err := readValue[T](br)
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		b, err := readValue[U](br)
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		if err := tmp.setFront(f, b); err != nil {
+			return err
+		}
+	}
+	if _, err := br.ReadByte(); err == nil {
+		return ErrTrailingData
+	}
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	return m.replaceChecked(tmp.front)
+}
+
+// unexpectedEOF turns io.EOF into io.ErrUnexpectedEOF for data that ends in the middle of the pairs
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
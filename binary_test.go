@@ -0,0 +1,182 @@
+package bimap
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"io"
+	"sort"
+	"testing"
+)
+
+func TestEncodeDecodeKeys(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "": 2, "hello world": 3}))
+	var buf bytes.Buffer
+	if err := m.EncodeKeys(&buf); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := DecodeKeys[string](&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(keys)
+	want := []string{"", "a", "hello world"}
+	if len(keys) != len(want) {
+		t.Fatalf("Lengths not equal, want: %d, got: %d", len(want), len(keys))
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Errorf("Keys not equal, want: %q, got: %q", want[i], keys[i])
+		}
+	}
+
+	n := New(WithInitialMap(map[int]string{7: "a", -3: "b"}))
+	buf.Reset()
+	if err := n.EncodeKeys(&buf); err != nil {
+		t.Fatal(err)
+	}
+	ints, err := DecodeKeys[int](&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Ints(ints)
+	if len(ints) != 2 || ints[0] != -3 || ints[1] != 7 {
+		t.Errorf("Unexpected keys: %v", ints)
+	}
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*BiMap[string, int])(nil)
+	_ encoding.BinaryUnmarshaler = (*BiMap[string, int])(nil)
+)
+
+func TestMarshalBinary(t *testing.T) {
+	pairs := map[string]int{"a": 1, "": 2, "hello world": 3, "z": -4}
+	a := New(WithInitialMap(pairs))
+	b := New[string, int]()
+	for _, k := range []string{"z", "hello world", "", "a"} {
+		b.SetFront(k, pairs[k])
+	}
+	data, err := a.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		other, err := b.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, other) {
+			t.Fatalf("Values not equal, want: %v, got: %v", data, other)
+		}
+	}
+
+	c := New(WithInitialMap(map[string]int{"old": 9}))
+	if err := c.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !sameContents(a, c) {
+		t.Errorf("Values not equal, want: %v, got: %v", a, c)
+	}
+
+	if err := c.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Errors not equal, want: %v, got: %v", io.ErrUnexpectedEOF, err)
+	}
+	if err := c.UnmarshalBinary(append(data, 0)); !errors.Is(err, ErrTrailingData) {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrTrailingData, err)
+	}
+	if !sameContents(a, c) {
+		t.Error("Failed unmarshal should not modify the map")
+	}
+
+	empty, err := New[string, int]().MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UnmarshalBinary(empty); err != nil || c.Len() != 0 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 0, c.Len())
+	}
+}
+
+func TestMarshalBinaryNonString(t *testing.T) {
+	type point struct {
+		X, Y int
+		Tag  [2]string
+	}
+	a := New(WithInitialMap(map[point]float64{{1, 2, [2]string{"a", ""}}: 0.5, {-3, 0, [2]string{}}: -1}))
+	data, err := a.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := New[point, float64]()
+	if err := b.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !sameContents(a, b) {
+		t.Errorf("Values not equal, want: %v, got: %v", a, b)
+	}
+}
+
+func TestMarshalBinaryUnsupported(t *testing.T) {
+	type hidden struct {
+		A int
+		b int
+	}
+	x := 1
+	check := func(name string, marshal func() ([]byte, error), unmarshal func([]byte) error) {
+		t.Helper()
+		if _, err := marshal(); !errors.Is(err, ErrUnsupportedType) {
+			t.Errorf("%s: Errors not equal, want: %v, got: %v", name, ErrUnsupportedType, err)
+		}
+		if err := unmarshal([]byte{0}); !errors.Is(err, ErrUnsupportedType) {
+			t.Errorf("%s: Errors not equal, want: %v, got: %v", name, ErrUnsupportedType, err)
+		}
+	}
+	anyKeys := New(WithInitialMap(map[any]int{"a": 1}))
+	check("any", anyKeys.MarshalBinary, anyKeys.UnmarshalBinary)
+	ptrKeys := New(WithInitialMap(map[*int]int{&x: 1}))
+	check("pointer", ptrKeys.MarshalBinary, ptrKeys.UnmarshalBinary)
+	hiddenVals := New(WithInitialMap(map[int]hidden{1: {1, 2}}))
+	check("unexported", hiddenVals.MarshalBinary, hiddenVals.UnmarshalBinary)
+	if err := anyKeys.EncodeKeys(io.Discard); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrUnsupportedType, err)
+	}
+	if _, err := DecodeKeys[*int](bytes.NewReader([]byte{0})); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrUnsupportedType, err)
+	}
+}
+
+func TestMarshalBinaryStableLayout(t *testing.T) {
+	type key struct {
+		A int8
+		B string
+	}
+	type other struct {
+		X [2]uint16
+		Y bool
+	}
+	m := New(WithInitialMap(map[key]int{{-1, "hi"}: 258}))
+	before, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(WithInitialMap(map[other]float32{{[2]uint16{1, 2}, true}: 1.5})).MarshalBinary(); err != nil {
+		t.Fatal(err)
+	}
+	after, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 1 pair, key of 4 bytes: int8 -1 and "hi", value of 8 bytes: 258
+	want := []byte{1, 4, 0xff, 2, 'h', 'i', 8, 0, 0, 0, 0, 0, 0, 1, 2}
+	if !bytes.Equal(before, want) || !bytes.Equal(after, want) {
+		t.Errorf("Values not equal, want: %v, got: %v and %v", want, before, after)
+	}
+	n := New[key, int]()
+	if err := n.UnmarshalBinary(after); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := n.GetFront(key{-1, "hi"}); v != 258 {
+		t.Errorf("Values not equal, want: %v, got: %v", 258, v)
+	}
+}
@@ -0,0 +1,43 @@
+package bimap
+
+// Token identifies a point in the change history of a BiMap object
+type Token uint64
+
+// change records the latest mutation of a key
+type change[U comparable] struct {
+	version uint64
+	val     U
+	deleted bool
+}
+
+// Checkpoint returns a token for the current state, changes are only tracked after the first call,
+// and one entry is kept for every key changed since then, including deleted keys
+func (m *BiMap[T, U]) Checkpoint() Token {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	if m.changes == nil {
+		m.changes = make(map[T]change[U])
+	}
+	return Token(m.version)
+}
+
+// ChangedSince iterates over the keys set or deleted after the checkpoint, with the current value of set keys
+// and the last value of deleted keys
+func (m *BiMap[T, U]) ChangedSince(tok Token, fn func(f T, b U, deleted bool)) {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	for f, c := range m.changes {
+		if c.version > uint64(tok) {
+			fn(f, c.val, c.deleted)
+		}
+	}
+}
+
+// track records the mutation of the key if changes are tracked, the caller must hold the write lock
+func (m *BiMap[T, U]) track(f T, b U, deleted bool) {
+	if m.changes == nil {
+		return
+	}
+	m.version++
+	m.changes[f] = change[U]{version: m.version, val: b, deleted: deleted}
+}
@@ -0,0 +1,64 @@
+package bimap
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestChangedSince(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2, "c": 3}))
+	m.SetFront("x", 9)
+	tok := m.Checkpoint()
+	m.SetFront("d", 4)
+	m.DeleteFront("b")
+	m.UpdateFront("c", func(old int) int {
+		return old * 10
+	})
+	type result struct {
+		val     int
+		deleted bool
+	}
+	got := make(map[string]result)
+	m.ChangedSince(tok, func(f string, b int, deleted bool) {
+		got[f] = result{b, deleted}
+	})
+	want := map[string]result{"d": {4, false}, "b": {2, true}, "c": {30, false}}
+	if len(got) != len(want) {
+		t.Fatalf("Unexpected changes: %v", got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("Changes not equal for %s, want: %+v, got: %+v", k, v, got[k])
+		}
+	}
+	tok = m.Checkpoint()
+	n := 0
+	m.ChangedSince(tok, func(string, int, bool) {
+		n++
+	})
+	if n != 0 {
+		t.Errorf("Counts not equal, want: %d, got: %d", 0, n)
+	}
+}
+
+func TestChangedSinceRollback(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	tok := m.Checkpoint()
+	errAbort := errors.New("abort")
+	m.Batch(func(tx *Tx[string, int]) error {
+		tx.DeleteFront("a")
+		tx.SetFront("c", 3)
+		return errAbort
+	})
+	m.Batch(func(tx *Tx[string, int]) error {
+		tx.DeleteFront("b")
+		return nil
+	})
+	got := make(map[string]bool)
+	m.ChangedSince(tok, func(f string, _ int, deleted bool) {
+		got[f] = deleted
+	})
+	if len(got) != 1 || !got["b"] {
+		t.Errorf("Unexpected changes: %v", got)
+	}
+}
@@ -0,0 +1,139 @@
+package bimap
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// CopyOnWriteBiMap is a variant of BiMap whose reads are lock-free, every write copies both maps
+// and atomically swaps them in, so it suits read-heavy workloads with rare writes
+type CopyOnWriteBiMap[T, U comparable] struct {
+	mu    sync.Mutex
+	state atomic.Pointer[cowState[T, U]]
+}
+
+// cowState is an immutable pair of maps
+type cowState[T, U comparable] struct {
+	front map[T]U
+	back  map[U]T
+}
+
+// NewCopyOnWrite returns a CopyOnWriteBiMap object
+func NewCopyOnWrite[T, U comparable]() *CopyOnWriteBiMap[T, U] {
+	m := &CopyOnWriteBiMap[T, U]{}
+	m.state.Store(&cowState[T, U]{
+		front: make(map[T]U),
+		back:  make(map[U]T),
+	})
+	return m
+}
+
+// clone returns a mutable copy of the state with room for extra pairs
+func (s *cowState[T, U]) clone(extra int) *cowState[T, U] {
+	ns := &cowState[T, U]{
+		front: make(map[T]U, len(s.front)+extra),
+		back:  make(map[U]T, len(s.back)+extra),
+	}
+	for k, v := range s.front {
+		ns.front[k] = v
+		ns.back[v] = k
+	}
+	return ns
+}
+
+// GetFront returns the value and its existence by the given key in front map
+func (m *CopyOnWriteBiMap[T, U]) GetFront(key T) (U, bool) {
+	v, ok := m.state.Load().front[key]
+	return v, ok
+}
+
+// GetBack returns the value and its existence by the given key in back map
+func (m *CopyOnWriteBiMap[T, U]) GetBack(key U) (T, bool) {
+	v, ok := m.state.Load().back[key]
+	return v, ok
+}
+
+// SetFront sets the value with corresponding key in the front map, it will return an error if either key or value exist
+func (m *CopyOnWriteBiMap[T, U]) SetFront(key T, val U) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.state.Load()
+	if _, ok := s.front[key]; ok {
+		return ErrKeyValExists
+	}
+	if _, ok := s.back[val]; ok {
+		return ErrKeyValExists
+	}
+	ns := s.clone(1)
+	ns.front[key] = val
+	ns.back[val] = key
+	m.state.Store(ns)
+	return nil
+}
+
+// SetBack sets the value with corresponding key in the back map, it will return an error if either key or value exist
+func (m *CopyOnWriteBiMap[T, U]) SetBack(key U, val T) error {
+	return m.SetFront(val, key)
+}
+
+// DeleteFront deletes the value of the given key in front map
+func (m *CopyOnWriteBiMap[T, _]) DeleteFront(key T) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.state.Load()
+	v, ok := s.front[key]
+	if !ok {
+		return
+	}
+	ns := s.clone(0)
+	delete(ns.front, key)
+	delete(ns.back, v)
+	m.state.Store(ns)
+}
+
+// DeleteBack deletes the value of the given key in back map
+func (m *CopyOnWriteBiMap[_, U]) DeleteBack(key U) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.state.Load()
+	v, ok := s.back[key]
+	if !ok {
+		return
+	}
+	ns := s.clone(0)
+	delete(ns.back, key)
+	delete(ns.front, v)
+	m.state.Store(ns)
+}
+
+// Front returns a new map object that contains all key-value pairs in front map
+func (m *CopyOnWriteBiMap[T, U]) Front() map[T]U {
+	s := m.state.Load()
+	nm := make(map[T]U, len(s.front))
+	for k, v := range s.front {
+		nm[k] = v
+	}
+	return nm
+}
+
+// Back returns a new map object that contains all key-value pairs in back map
+func (m *CopyOnWriteBiMap[T, U]) Back() map[U]T {
+	s := m.state.Load()
+	nm := make(map[U]T, len(s.back))
+	for k, v := range s.back {
+		nm[k] = v
+	}
+	return nm
+}
+
+// Len returns the length of the CopyOnWriteBiMap object
+func (m *CopyOnWriteBiMap[_, _]) Len() int {
+	return len(m.state.Load().front)
+}
+
+// For iterate over the map for the given function, writes during the iteration are not visible to it
+func (m *CopyOnWriteBiMap[T, U]) For(fn func(f T, b U)) {
+	for f, b := range m.state.Load().front {
+		fn(f, b)
+	}
+}
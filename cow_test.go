@@ -0,0 +1,56 @@
+package bimap
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestCopyOnWrite(t *testing.T) {
+	m := NewCopyOnWrite[string, int]()
+	if err := m.SetFront("a", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetBack(2, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetFront("c", 1); err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+	front := m.Front()
+	m.DeleteBack(1)
+	if _, ok := m.GetFront("a"); ok {
+		t.Error("Should be deleted")
+	}
+	if front["a"] != 1 {
+		t.Error("Earlier copies should not be affected")
+	}
+	m.DeleteFront("b")
+	if l := m.Len(); l != 0 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 0, l)
+	}
+}
+
+func BenchmarkReadBiMap(b *testing.B) {
+	m := newBenchMap(1024)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.GetFront(strconv.Itoa(i % 1024))
+			i++
+		}
+	})
+}
+
+func BenchmarkReadCopyOnWrite(b *testing.B) {
+	m := NewCopyOnWrite[string, int]()
+	for i := 0; i < 1024; i++ {
+		m.SetFront(strconv.Itoa(i), i)
+	}
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.GetFront(strconv.Itoa(i % 1024))
+			i++
+		}
+	})
+}
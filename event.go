@@ -0,0 +1,91 @@
+package bimap
+
+import "sync"
+
+// EventKind describes the kind of mutation in an Event
+type EventKind int
+
+const (
+	// EventSet means the pair was set
+	EventSet EventKind = iota
+	// EventDelete means the pair was deleted
+	EventDelete
+)
+
+// Event describes a mutation of a pair in the BiMap object
+type Event[T, U comparable] struct {
+	Kind  EventKind
+	Front T
+	Back  U
+}
+
+// Subscribe returns a channel that receives every subsequent mutation and a function to unsubscribe,
+// events are dropped if the channel buffer is full
+func (m *BiMap[T, U]) Subscribe(buffer int) (<-chan Event[T, U], func()) {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	if m.subs == nil {
+		m.subs = make(map[int]chan Event[T, U])
+	}
+	id := m.nextSub
+	m.nextSub++
+	ch := make(chan Event[T, U], buffer)
+	m.subs[id] = ch
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			m.rwLock.Lock()
+			defer m.rwLock.Unlock()
+			delete(m.subs, id)
+			close(ch)
+		})
+	}
+}
+
+type observerOption[T, U comparable] func(Event[T, U])
+
+func (oo observerOption[T, U]) apply(m *BiMap[T, U]) {
+	m.observers = append(m.observers, oo)
+}
+
+// WithObserver returns an observerOption object that implements the option interface,
+// fn is called with every mutation while the write lock is held so it must not call methods of the BiMap object,
+// a panic in fn is recovered and passed to the handler given by WithPanicHandler
+func WithObserver[T, U comparable](fn func(Event[T, U])) option[T, U] {
+	return observerOption[T, U](fn)
+}
+
+type panicHandlerOption[T, U comparable] func(any)
+
+func (po panicHandlerOption[T, U]) apply(m *BiMap[T, U]) {
+	m.panicHandler = po
+}
+
+// WithPanicHandler returns a panicHandlerOption object that implements the option interface,
+// fn receives the values recovered from panicking observers, which are discarded if no handler is given
+func WithPanicHandler[T, U comparable](fn func(any)) option[T, U] {
+	return panicHandlerOption[T, U](fn)
+}
+
+// notify sends the event to all subscribers without blocking and calls all observers, the caller must hold the write lock
+func (m *BiMap[T, U]) notify(e Event[T, U]) {
+	for _, ch := range m.subs {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+	for _, fn := range m.observers {
+		m.observe(fn, e)
+	}
+}
+
+// observe calls the observer and recovers from its panic
+func (m *BiMap[T, U]) observe(fn func(Event[T, U]), e Event[T, U]) {
+	defer func() {
+		if r := recover(); r != nil && m.panicHandler != nil {
+			m.panicHandler(r)
+		}
+	}()
+	fn(e)
+}
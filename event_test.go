@@ -0,0 +1,64 @@
+package bimap
+
+import "testing"
+
+func TestSubscribe(t *testing.T) {
+	m := New[string, int]()
+	ch, unsubscribe := m.Subscribe(4)
+	m.SetFront("a", 1)
+	m.DeleteBack(1)
+	want := []Event[string, int]{
+		{Kind: EventSet, Front: "a", Back: 1},
+		{Kind: EventDelete, Front: "a", Back: 1},
+	}
+	for _, w := range want {
+		if e := <-ch; e != w {
+			t.Errorf("Events not equal, want: %+v, got: %+v", w, e)
+		}
+	}
+	unsubscribe()
+	m.SetFront("b", 2)
+	if _, ok := <-ch; ok {
+		t.Error("Should not receive events after unsubscribing")
+	}
+	unsubscribe()
+}
+
+func TestObserverPanic(t *testing.T) {
+	var events []Event[string, int]
+	var recovered []any
+	m := New(
+		WithObserver(func(e Event[string, int]) {
+			panic("boom")
+		}),
+		WithObserver(func(e Event[string, int]) {
+			events = append(events, e)
+		}),
+		WithPanicHandler[string, int](func(r any) {
+			recovered = append(recovered, r)
+		}),
+	)
+	if err := m.SetFront("a", 1); err != nil {
+		t.Fatal(err)
+	}
+	m.DeleteFront("a")
+	if len(recovered) != 2 || recovered[0] != "boom" {
+		t.Errorf("Unexpected recovered values: %v", recovered)
+	}
+	if len(events) != 2 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 2, len(events))
+	}
+	if err := m.SetFront("b", 2); err != nil {
+		t.Error(err)
+	}
+	if frontOnly, backOnly := m.Orphans(); len(frontOnly) != 0 || len(backOnly) != 0 {
+		t.Error("Map should stay consistent")
+	}
+	m = New(WithObserver(func(e Event[string, int]) {
+		panic("boom")
+	}))
+	m.SetFront("a", 1)
+	if l := m.Len(); l != 1 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 1, l)
+	}
+}
@@ -0,0 +1,78 @@
+package bimap
+
+import "errors"
+
+var ErrFrozen = errors.New("map is frozen")
+
+// Freeze rejects all modifications until Unfreeze is called, methods that return an error return ErrFrozen
+// and the other methods that modify the map do nothing. Reads are not affected
+func (m *BiMap[_, _]) Freeze() {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	m.held = true
+	m.frozen = true
+}
+
+// Unfreeze allows modifications again, unless a SwapContents call is in progress until it completes
+func (m *BiMap[_, _]) Unfreeze() {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	m.held = false
+	m.frozen = m.swapping
+}
+
+// Frozen reports whether the BiMap object is frozen by Freeze or a SwapContents call in progress
+func (m *BiMap[_, _]) Frozen() bool {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	return m.frozen
+}
+
+// SwapContents freezes the BiMap object while the given pairs are validated, then replaces all pairs with them
+// and unfreezes it, returning the previous front map. Reads see the old pairs until the swap, and other writes
+// are rejected with ErrFrozen meanwhile. It returns ErrFrozen if the BiMap object is already frozen,
+// and leaves the old pairs in place if a value repeats or a validator fails. Freeze and Unfreeze calls during
+// the swap do not stop it and take effect once it completes
+func (m *BiMap[T, U]) SwapContents(pairs map[T]U) (old map[T]U, err error) {
+	m.rwLock.Lock()
+	if m.frozen {
+		m.rwLock.Unlock()
+		return nil, ErrFrozen
+	}
+	m.swapping = true
+	m.frozen = true
+	m.rwLock.Unlock()
+
+	err = m.prepareSwap(pairs)
+
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	m.swapping = false
+	m.frozen = m.held
+	// unlike replaceChecked the validators ran in prepareSwap, they may read the map and cannot run under the lock
+	if err == nil {
+		err = m.checkGrowOnly(pairs)
+	}
+	if err != nil {
+		return nil, err
+	}
+	old = make(map[T]U, len(m.front))
+	for k, v := range m.front {
+		old[k] = v
+	}
+	m.replace(pairs)
+	return old, nil
+}
+
+// prepareSwap validates the pairs without holding the lock
+func (m *BiMap[T, U]) prepareSwap(pairs map[T]U) error {
+	if ok, _ := IsInjective(pairs); !ok {
+		return ErrKeyValExists
+	}
+	for k, v := range pairs {
+		if err := m.validate(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package bimap
+
+import "testing"
+
+func TestFreeze(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	m.Freeze()
+	if !m.Frozen() {
+		t.Error("Should be frozen")
+	}
+	if err := m.SetFront("c", 3); err != ErrFrozen {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrFrozen, err)
+	}
+	if val, ok := m.UpdateFront("a", func(int) int { return 10 }); !ok || val != 1 {
+		t.Errorf("Values not equal, want: %d, got: %d", 1, val)
+	}
+	if _, err := m.UpdateFrontIfPresent("a", 10); err != ErrFrozen {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrFrozen, err)
+	}
+	if m.DeleteFrontReport("a") {
+		t.Error("Delete should be rejected")
+	}
+	m.Clear()
+	if d := m.SetFrontDisplacing("a", 2); d != nil {
+		t.Errorf("Unexpected displaced pairs: %v", d)
+	}
+	if err := m.Batch(func(tx *Tx[string, int]) error { return nil }); err != ErrFrozen {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrFrozen, err)
+	}
+	if val, ok := m.GetFront("a"); !ok || val != 1 {
+		t.Errorf("Values not equal, want: %d, got: %d", 1, val)
+	}
+	if l := m.Len(); l != 2 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 2, l)
+	}
+	m.Unfreeze()
+	if err := m.SetFront("c", 3); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSwapContents(t *testing.T) {
+	var m *BiMap[string, int]
+	var readErr, writeErr error
+	m = New(
+		WithInitialMap(map[string]int{"a": 1}),
+		WithValidator(func(f string, b int) error {
+			// runs while the swap is prepared
+			if m.Frozen() {
+				if val, ok := m.GetFront("a"); !ok || val != 1 {
+					readErr = ErrValueMismatch
+				}
+				writeErr = m.SetFront("z", 26)
+			}
+			return nil
+		}),
+	)
+	old, err := m.SwapContents(map[string]int{"b": 2, "c": 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if readErr != nil {
+		t.Error("Reads during preparation should see the old pairs")
+	}
+	if writeErr != ErrFrozen {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrFrozen, writeErr)
+	}
+	if len(old) != 1 || old["a"] != 1 {
+		t.Errorf("Unexpected old contents: %v", old)
+	}
+	if front := m.Front(); len(front) != 2 || front["b"] != 2 || front["c"] != 3 {
+		t.Errorf("Unexpected contents: %v", front)
+	}
+	if m.Frozen() {
+		t.Error("Should be unfrozen after the swap")
+	}
+	if _, err := m.SwapContents(map[string]int{"x": 1, "y": 1}); err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+	if l := m.Len(); l != 2 || m.Frozen() {
+		t.Error("Failed swap should keep the old pairs and unfreeze")
+	}
+	m.Freeze()
+	if _, err := m.SwapContents(map[string]int{"x": 1}); err != ErrFrozen {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrFrozen, err)
+	}
+}
+
+func TestSwapContentsFreezeDuringSwap(t *testing.T) {
+	var m *BiMap[string, int]
+	var unfreeze bool
+	var writeErr error
+	m = New(
+		WithInitialMap(map[string]int{"a": 1}),
+		WithValidator(func(f string, b int) error {
+			if f != "b" {
+				return nil
+			}
+			if unfreeze {
+				m.Unfreeze()
+				writeErr = m.SetFront("z", 26)
+			} else {
+				m.Freeze()
+			}
+			return nil
+		}),
+	)
+	if _, err := m.SwapContents(map[string]int{"b": 2}); err != nil {
+		t.Fatal(err)
+	}
+	if !m.Frozen() {
+		t.Error("Freeze during the swap should be kept")
+	}
+
+	m.Unfreeze()
+	unfreeze = true
+	if _, err := m.SwapContents(map[string]int{"b": 2, "c": 3}); err != nil {
+		t.Fatal(err)
+	}
+	if writeErr != ErrFrozen {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrFrozen, writeErr)
+	}
+	if m.Frozen() {
+		t.Error("Should be unfrozen after the swap")
+	}
+	if front := m.Front(); len(front) != 2 || front["b"] != 2 || front["c"] != 3 {
+		t.Errorf("Unexpected contents: %v", front)
+	}
+}
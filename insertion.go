@@ -0,0 +1,109 @@
+package bimap
+
+type insertionOrderOption[T, U comparable] struct{}
+
+func (insertionOrderOption[T, U]) apply(m *BiMap[T, U]) {
+	m.orderIdx = make(map[T]int, len(m.front))
+	for k := range m.front {
+		m.insertOrder(k)
+	}
+}
+
+// WithInsertionOrder returns an insertionOrderOption object that implements the option interface,
+// the order in which keys are inserted is tracked for Oldest and ForInserted. Pairs already given by
+// an earlier WithInitialMap are recorded in no particular order, and setting a new value for a key
+// counts as a new insertion
+func WithInsertionOrder[T, U comparable]() option[T, U] {
+	return insertionOrderOption[T, U]{}
+}
+
+// insertOrder appends the key to the order if insertion order is tracked and the key is not in it yet,
+// the caller must hold the write lock
+func (m *BiMap[T, U]) insertOrder(f T) {
+	if m.orderIdx == nil {
+		return
+	}
+	if _, ok := m.orderIdx[f]; ok {
+		return
+	}
+	m.orderIdx[f] = len(m.order)
+	m.order = append(m.order, f)
+}
+
+// deleteOrder leaves a tombstone for the key if insertion order is tracked, the caller must hold the write lock
+func (m *BiMap[T, U]) deleteOrder(f T) {
+	if m.orderIdx == nil {
+		return
+	}
+	delete(m.orderIdx, f)
+	for m.orderHead < len(m.order) && !m.orderLive(m.orderHead) {
+		m.orderHead++
+	}
+}
+
+// orderLive reports whether the i-th entry of the order is the current position of its key
+func (m *BiMap[T, U]) orderLive(i int) bool {
+	idx, ok := m.orderIdx[m.order[i]]
+	return ok && idx == i
+}
+
+// Oldest returns the earliest inserted pair that is still present,
+// ok is false if the map is empty or insertion order is not tracked
+func (m *BiMap[T, U]) Oldest() (f T, b U, ok bool) {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	for i := m.orderHead; i < len(m.order); i++ {
+		if m.orderLive(i) {
+			f = m.order[i]
+			return f, m.front[f], true
+		}
+	}
+	return f, b, false
+}
+
+// newest returns the latest inserted pair that is still present, the caller must hold the lock
+func (m *BiMap[T, U]) newest() (f T, b U, ok bool) {
+	for i := len(m.order) - 1; i >= m.orderHead; i-- {
+		if m.orderLive(i) {
+			f = m.order[i]
+			return f, m.front[f], true
+		}
+	}
+	return f, b, false
+}
+
+// ForInserted iterates over the pairs from the oldest to the newest insertion until fn returns false,
+// it does nothing if insertion order is not tracked
+func (m *BiMap[T, U]) ForInserted(fn func(f T, b U) bool) {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	for i := m.orderHead; i < len(m.order); i++ {
+		if !m.orderLive(i) {
+			continue
+		}
+		f := m.order[i]
+		if !fn(f, m.front[f]) {
+			return
+		}
+	}
+}
+
+// CompactOrder removes the tombstones left in the insertion order by deleted keys, keeping the order of
+// the remaining keys. The order grows with every insertion until compacted, so it is worth calling after
+// many deletes of keys other than the oldest, deleting the oldest keys reclaims nothing by itself
+func (m *BiMap[T, U]) CompactOrder() {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	if m.orderIdx == nil {
+		return
+	}
+	order := make([]T, 0, len(m.orderIdx))
+	for i := m.orderHead; i < len(m.order); i++ {
+		if m.orderLive(i) {
+			f := m.order[i]
+			m.orderIdx[f] = len(order)
+			order = append(order, f)
+		}
+	}
+	m.order, m.orderHead = order, 0
+}
@@ -0,0 +1,103 @@
+package bimap
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func inserted[T, U comparable](m *BiMap[T, U]) []T {
+	var keys []T
+	m.ForInserted(func(f T, _ U) bool {
+		keys = append(keys, f)
+		return true
+	})
+	return keys
+}
+
+func TestOldest(t *testing.T) {
+	m := New(WithInsertionOrder[string, int]())
+	if _, _, ok := m.Oldest(); ok {
+		t.Errorf("Values not equal, want: %v, got: %v", false, ok)
+	}
+	m.SetFront("a", 1)
+	m.SetFront("b", 2)
+	m.SetFront("c", 3)
+	if f, b, ok := m.Oldest(); !ok || f != "a" || b != 1 {
+		t.Errorf("Values not equal, want: %v, got: %v", "a 1 true", []any{f, b, ok})
+	}
+	m.DeleteFront("a")
+	if f, b, ok := m.Oldest(); !ok || f != "b" || b != 2 {
+		t.Errorf("Values not equal, want: %v, got: %v", "b 2 true", []any{f, b, ok})
+	}
+	m.SetFront("a", 4)
+	m.DeleteFront("b")
+	if f, b, ok := m.Oldest(); !ok || f != "c" || b != 3 {
+		t.Errorf("Values not equal, want: %v, got: %v", "c 3 true", []any{f, b, ok})
+	}
+	if keys := inserted(m); !reflect.DeepEqual(keys, []string{"c", "a"}) {
+		t.Errorf("Values not equal, want: %v, got: %v", []string{"c", "a"}, keys)
+	}
+	m.Clear()
+	if _, _, ok := m.Oldest(); ok {
+		t.Errorf("Values not equal, want: %v, got: %v", false, ok)
+	}
+}
+
+func TestOldestUntracked(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1}))
+	if _, _, ok := m.Oldest(); ok {
+		t.Errorf("Values not equal, want: %v, got: %v", false, ok)
+	}
+}
+
+func TestCompactOrder(t *testing.T) {
+	m := New(WithInsertionOrder[int, int]())
+	for i := 0; i < 10; i++ {
+		m.SetFront(i, i)
+	}
+	for i := 0; i < 10; i += 3 {
+		m.DeleteFront(i)
+	}
+	m.SetFront(0, 10)
+	want := []int{1, 2, 4, 5, 7, 8, 0}
+	if keys := inserted(m); !reflect.DeepEqual(keys, want) {
+		t.Errorf("Values not equal, want: %v, got: %v", want, keys)
+	}
+	m.CompactOrder()
+	if len(m.order) != len(want) {
+		t.Errorf("Lengths not equal, want: %d, got: %d", len(want), len(m.order))
+	}
+	if keys := inserted(m); !reflect.DeepEqual(keys, want) {
+		t.Errorf("Values not equal, want: %v, got: %v", want, keys)
+	}
+	m.DeleteFront(1)
+	m.SetFront(11, 11)
+	want = []int{2, 4, 5, 7, 8, 0, 11}
+	if keys := inserted(m); !reflect.DeepEqual(keys, want) {
+		t.Errorf("Values not equal, want: %v, got: %v", want, keys)
+	}
+	if f, _, _ := m.Oldest(); f != 2 {
+		t.Errorf("Values not equal, want: %v, got: %v", 2, f)
+	}
+}
+
+func TestOrderAfterRollback(t *testing.T) {
+	m := New(WithInsertionOrder[string, int]())
+	m.SetFront("a", 1)
+	m.SetFront("b", 2)
+	m.SetFront("c", 3)
+	m.Batch(func(tx *Tx[string, int]) error {
+		tx.DeleteFront("a")
+		tx.DeleteFront("c")
+		tx.SetFront("c", 3)
+		return errors.New("abort")
+	})
+	if f, _, _ := m.Oldest(); f != "a" {
+		t.Errorf("Values not equal, want: %v, got: %v", "a", f)
+	}
+	want := []string{"a", "b", "c"}
+	if keys := inserted(m); !reflect.DeepEqual(keys, want) {
+		t.Errorf("Values not equal, want: %v, got: %v", want, keys)
+	}
+}
@@ -0,0 +1,105 @@
+package bimap
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+)
+
+// jsonlPair is a line of the layout of WriteJSONL
+type jsonlPair[T, U comparable] struct {
+	Front T `json:"front"`
+	Back  U `json:"back"`
+}
+
+// MarshalJSONPairs returns the front map encoded as a JSON array of [key, value] arrays sorted by the encoded key
+func (m *BiMap[T, U]) MarshalJSONPairs() ([]byte, error) {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	pairs := make([][2]json.RawMessage, 0, len(m.front))
+	for f, b := range m.front {
+		k, err := json.Marshal(f)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(b)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, [2]json.RawMessage{k, v})
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return bytes.Compare(pairs[i][0], pairs[j][0]) < 0
+	})
+	return json.Marshal(pairs)
+}
+
+// UnmarshalJSONPairs replaces the contents with the pairs decoded from the layout of MarshalJSONPairs,
+// it will return an error without modification if either key or value repeats or a validator fails
+func (m *BiMap[T, U]) UnmarshalJSONPairs(data []byte) error {
+	var raw [][2]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	front := make(map[T]U, len(raw))
+	back := make(map[U]T, len(raw))
+	for _, p := range raw {
+		var f T
+		var b U
+		if err := json.Unmarshal(p[0], &f); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(p[1], &b); err != nil {
+			return err
+		}
+		if _, ok := front[f]; ok {
+			return ErrKeyValExists
+		}
+		if _, ok := back[b]; ok {
+			return ErrKeyValExists
+		}
+		front[f] = b
+		back[b] = f
+	}
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	return m.replaceChecked(front)
+}
+
+// WriteJSONL writes every pair as a JSON object with front and back fields on its own line
+func (m *BiMap[T, U]) WriteJSONL(w io.Writer) error {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	enc := json.NewEncoder(w)
+	for f, b := range m.front {
+		if err := enc.Encode(jsonlPair[T, U]{Front: f, Back: b}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadJSONL replaces the contents with the pairs read from the layout of WriteJSONL,
+// it will return an error without modification if either key or value repeats
+func (m *BiMap[T, U]) ReadJSONL(r io.Reader) error {
+	tmp := New[T, U]()
+	dec := json.NewDecoder(r)
+	for {
+		var p jsonlPair[T, U]
+		err := dec.Decode(&p)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := tmp.setFront(p.Front, p.Back); err != nil {
+			return err
+		}
+	}
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	return m.replaceChecked(tmp.front)
+}
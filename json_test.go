@@ -0,0 +1,64 @@
+package bimap
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestJSONPairs(t *testing.T) {
+	m := New(WithInitialMap(map[int]string{2: "b", 10: "j", 1: "a"}))
+	data, err := m.MarshalJSONPairs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[[1,"a"],[10,"j"],[2,"b"]]`
+	if string(data) != want {
+		t.Errorf("Outputs not equal, want: %s, got: %s", want, data)
+	}
+	n := New(WithInitialMap(map[int]string{5: "e"}))
+	if err := n.UnmarshalJSONPairs(data); err != nil {
+		t.Fatal(err)
+	}
+	if !n.EqualFunc(m, func(a, b string) bool { return a == b }) {
+		t.Errorf("Maps not equal, want: %v, got: %v", m, n)
+	}
+	if key, _ := n.GetBack("j"); key != 10 {
+		t.Errorf("Values not equal, want: %d, got: %d", 10, key)
+	}
+	if err := n.UnmarshalJSONPairs([]byte(`[[1,"a"],[2,"a"]]`)); err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+	if l := n.Len(); l != 3 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 3, l)
+	}
+}
+
+func TestJSONL(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2, "c": 3}))
+	var buf bytes.Buffer
+	if err := m.WriteJSONL(&buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Lengths not equal, want: %d, got: %d", 3, len(lines))
+	}
+	if !strings.HasPrefix(lines[0], `{"front":`) {
+		t.Errorf("Unexpected line: %s", lines[0])
+	}
+	n := New[string, int]()
+	if err := n.ReadJSONL(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !sameContents(m, n) {
+		t.Errorf("Maps not equal, want: %v, got: %v", m, n)
+	}
+	err := n.ReadJSONL(strings.NewReader(`{"front":"x","back":1}` + "\n" + `{"front":"y","back":1}` + "\n"))
+	if err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+	if !sameContents(m, n) {
+		t.Errorf("Map should not be modified, got: %v", n)
+	}
+}
@@ -0,0 +1,79 @@
+package bimap
+
+import "sync"
+
+// LazyBiMap is a BiMap object that is built from its init function on first access
+type LazyBiMap[T, U comparable] struct {
+	once sync.Once
+	init func() map[T]U
+	m    *BiMap[T, U]
+}
+
+// Lazy returns a LazyBiMap object that calls init once on first access to build the underlying BiMap object
+func Lazy[T, U comparable](init func() map[T]U) *LazyBiMap[T, U] {
+	return &LazyBiMap[T, U]{init: init}
+}
+
+// BiMap returns the underlying BiMap object, building it if needed
+func (l *LazyBiMap[T, U]) BiMap() *BiMap[T, U] {
+	l.once.Do(func() {
+		l.m = New(WithInitialMap(l.init()))
+		l.init = nil
+	})
+	return l.m
+}
+
+// GetFront returns the value and its existence by the given key in front map
+func (l *LazyBiMap[T, U]) GetFront(key T) (U, bool) {
+	return l.BiMap().GetFront(key)
+}
+
+// GetBack returns the value and its existence by the given key in back map
+func (l *LazyBiMap[T, U]) GetBack(key U) (T, bool) {
+	return l.BiMap().GetBack(key)
+}
+
+// SetFront sets the value with corresponding key in the front map, it will return an error if either key or value exist
+func (l *LazyBiMap[T, U]) SetFront(key T, val U) error {
+	return l.BiMap().SetFront(key, val)
+}
+
+// SetBack sets the value with corresponding key in the back map, it will return an error if either key or value exist
+func (l *LazyBiMap[T, U]) SetBack(key U, val T) error {
+	return l.BiMap().SetBack(key, val)
+}
+
+// DeleteFront deletes the value of the given key in front map
+func (l *LazyBiMap[T, _]) DeleteFront(key T) {
+	l.BiMap().DeleteFront(key)
+}
+
+// DeleteBack deletes the value of the given key in back map
+func (l *LazyBiMap[_, U]) DeleteBack(key U) {
+	l.BiMap().DeleteBack(key)
+}
+
+// Front returns a new map object that contains all key-value pairs in front map
+func (l *LazyBiMap[T, U]) Front() map[T]U {
+	return l.BiMap().Front()
+}
+
+// Back returns a new map object that contains all key-value pairs in back map
+func (l *LazyBiMap[T, U]) Back() map[U]T {
+	return l.BiMap().Back()
+}
+
+// Len returns the length of the BiMap object
+func (l *LazyBiMap[_, _]) Len() int {
+	return l.BiMap().Len()
+}
+
+// For iterate over the map for the given function
+func (l *LazyBiMap[T, U]) For(fn func(f T, b U)) {
+	l.BiMap().For(fn)
+}
+
+// String returns a string representation the BiMap object
+func (l *LazyBiMap[_, _]) String() string {
+	return l.BiMap().String()
+}
@@ -0,0 +1,35 @@
+package bimap
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestLazy(t *testing.T) {
+	calls := 0
+	l := Lazy(func() map[string]int {
+		calls++
+		return map[string]int{"a": 1}
+	})
+	if calls != 0 {
+		t.Fatal("init should not run before first access")
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.GetFront("a")
+		}()
+	}
+	wg.Wait()
+	if err := l.SetFront("b", 2); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("Calls not equal, want: %d, got: %d", 1, calls)
+	}
+	if l := l.Len(); l != 2 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 2, l)
+	}
+}
@@ -0,0 +1,103 @@
+package bimap
+
+import "sync"
+
+// loadCall is an in-flight or completed call to the loader
+type loadCall[U comparable] struct {
+	wg  sync.WaitGroup
+	val U
+	ok  bool
+}
+
+type loaderOption[T, U comparable] func(T) (U, bool)
+
+func (lo loaderOption[T, U]) apply(m *BiMap[T, U]) {
+	m.loader = lo
+}
+
+// WithLoader returns a loaderOption object that implements the option interface,
+// GetFront calls load for absent keys and sets the loaded pair, concurrent misses of the same key share one call.
+// If the loaded value belongs to another key it is returned but not set
+func WithLoader[T, U comparable](load func(T) (U, bool)) option[T, U] {
+	return loaderOption[T, U](load)
+}
+
+// load calls the loader for the key unless it has been set meanwhile, or waits for the call in flight for the same key
+func (m *BiMap[T, U]) load(key T) (U, bool) {
+	m.loadMu.Lock()
+	if c, ok := m.loads[key]; ok {
+		m.loadMu.Unlock()
+		c.wg.Wait()
+		return c.val, c.ok
+	}
+	c := &loadCall[U]{}
+	c.wg.Add(1)
+	if m.loads == nil {
+		m.loads = make(map[T]*loadCall[U])
+	}
+	m.loads[key] = c
+	m.loadMu.Unlock()
+
+	defer func() {
+		m.loadMu.Lock()
+		delete(m.loads, key)
+		m.loadMu.Unlock()
+		c.wg.Done()
+	}()
+	// a call that finished after the caller missed has already set the pair
+	m.rwLock.RLock()
+	c.val, c.ok = m.front[key]
+	m.rwLock.RUnlock()
+	if c.ok {
+		return c.val, c.ok
+	}
+	c.val, c.ok = m.loader(key)
+	if c.ok {
+		m.rwLock.Lock()
+		defer m.rwLock.Unlock()
+		if v, exists := m.front[key]; exists {
+			c.val = v
+		} else {
+			m.setFront(key, c.val)
+		}
+	}
+	return c.val, c.ok
+}
+
+// GetFrontManyOrLoad returns the values of the given keys, calling load once with the keys that are absent.
+// Loaded pairs are set like with WithLoader, a loaded value that belongs to another key is returned but not set,
+// and keys that are neither present nor loaded are left out of the result
+func (m *BiMap[T, U]) GetFrontManyOrLoad(keys []T, load func([]T) map[T]U) map[T]U {
+	res := make(map[T]U, len(keys))
+	var misses []T
+	missed := make(map[T]struct{})
+	m.rwLock.RLock()
+	for _, k := range keys {
+		if v, ok := m.front[k]; ok {
+			res[k] = v
+		} else if _, ok := missed[k]; !ok {
+			missed[k] = struct{}{}
+			misses = append(misses, k)
+		}
+	}
+	m.rwLock.RUnlock()
+	if len(misses) == 0 {
+		return res
+	}
+	loaded := load(misses)
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	for _, k := range misses {
+		v, ok := loaded[k]
+		if !ok {
+			continue
+		}
+		if cur, exists := m.front[k]; exists {
+			v = cur
+		} else {
+			m.setFront(k, v)
+		}
+		res[k] = v
+	}
+	return res
+}
@@ -0,0 +1,112 @@
+package bimap
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithLoader(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	m := New(WithLoader(func(key string) (int, bool) {
+		calls.Add(1)
+		<-release
+		if key == "missing" {
+			return 0, false
+		}
+		return len(key), true
+	}))
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if val, ok := m.GetFront("abc"); !ok || val != 3 {
+				t.Errorf("Values not equal, want: %d, got: %d", 3, val)
+			}
+		}()
+	}
+	for calls.Load() == 0 {
+		runtime.Gosched()
+	}
+	close(release)
+	wg.Wait()
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Calls not equal, want: %d, got: %d", 1, n)
+	}
+	if key, _ := m.GetBack(3); key != "abc" {
+		t.Errorf("Values not equal, want: %s, got: %s", "abc", key)
+	}
+	m.GetFront("abc")
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Hit should not call the loader, calls: %d", n)
+	}
+	if _, ok := m.GetFront("missing"); ok {
+		t.Error("Key should not exist")
+	}
+	if val, ok := m.GetFront("xyz"); !ok || val != 3 {
+		t.Errorf("Values not equal, want: %d, got: %d", 3, val)
+	}
+	if _, ok := m.GetBack(3); !ok {
+		t.Error("Existing pair should be kept")
+	}
+	if l := m.Len(); l != 1 {
+		t.Errorf("Colliding value should not be set, length: %d", l)
+	}
+}
+
+func TestLoaderCachedMeanwhile(t *testing.T) {
+	var calls atomic.Int32
+	m := New(WithLoader(func(key string) (int, bool) {
+		calls.Add(1)
+		return len(key), true
+	}))
+	m.SetFront("abc", 7)
+	// a GetFront that missed before the pair was set
+	if val, ok := m.load("abc"); !ok || val != 7 {
+		t.Errorf("Values not equal, want: %d, got: %d", 7, val)
+	}
+	if n := calls.Load(); n != 0 {
+		t.Errorf("Calls not equal, want: %d, got: %d", 0, n)
+	}
+}
+
+func TestGetFrontManyOrLoad(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	var calls int
+	var requested []string
+	res := m.GetFrontManyOrLoad([]string{"a", "c", "b", "d", "c", "e"}, func(keys []string) map[string]int {
+		calls++
+		requested = keys
+		return map[string]int{"c": 3, "d": 1, "x": 9}
+	})
+	if calls != 1 {
+		t.Errorf("Calls not equal, want: %d, got: %d", 1, calls)
+	}
+	if !reflect.DeepEqual(requested, []string{"c", "d", "e"}) {
+		t.Errorf("Values not equal, want: %v, got: %v", []string{"c", "d", "e"}, requested)
+	}
+	want := map[string]int{"a": 1, "b": 2, "c": 3, "d": 1}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("Values not equal, want: %v, got: %v", want, res)
+	}
+	if val, ok := m.GetFront("c"); !ok || val != 3 {
+		t.Errorf("Values not equal, want: %d, got: %d", 3, val)
+	}
+	if _, ok := m.GetFront("d"); ok {
+		t.Error("Colliding value should not be set")
+	}
+	if _, ok := m.GetFront("x"); ok {
+		t.Error("Key that was not requested should not be set")
+	}
+	m.GetFrontManyOrLoad([]string{"a", "c"}, func([]string) map[string]int {
+		calls++
+		return nil
+	})
+	if calls != 1 {
+		t.Errorf("Hits should not call load, calls: %d", calls)
+	}
+}
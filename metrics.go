@@ -0,0 +1,68 @@
+package bimap
+
+import "sync/atomic"
+
+// BiMapMetrics is a point-in-time copy of the operation counters of a BiMap object
+type BiMapMetrics struct {
+	Sets      uint64
+	Gets      uint64
+	Deletes   uint64
+	Conflicts uint64
+}
+
+// metrics holds the operation counters, all methods are no-ops on a nil receiver
+type metrics struct {
+	sets      atomic.Uint64
+	gets      atomic.Uint64
+	deletes   atomic.Uint64
+	conflicts atomic.Uint64
+}
+
+func (mt *metrics) set() {
+	if mt != nil {
+		mt.sets.Add(1)
+	}
+}
+
+func (mt *metrics) get() {
+	if mt != nil {
+		mt.gets.Add(1)
+	}
+}
+
+func (mt *metrics) delete() {
+	if mt != nil {
+		mt.deletes.Add(1)
+	}
+}
+
+func (mt *metrics) conflict() {
+	if mt != nil {
+		mt.conflicts.Add(1)
+	}
+}
+
+type metricsOption[T, U comparable] struct{}
+
+func (metricsOption[T, U]) apply(m *BiMap[T, U]) {
+	m.metrics = &metrics{}
+}
+
+// WithMetrics returns a metricsOption object that implements the option interface,
+// it enables counting sets, gets, deletes and conflicts
+func WithMetrics[T, U comparable]() option[T, U] {
+	return metricsOption[T, U]{}
+}
+
+// Metrics returns the operation counters, they are all zero if the BiMap object is not created WithMetrics
+func (m *BiMap[_, _]) Metrics() BiMapMetrics {
+	if m.metrics == nil {
+		return BiMapMetrics{}
+	}
+	return BiMapMetrics{
+		Sets:      m.metrics.sets.Load(),
+		Gets:      m.metrics.gets.Load(),
+		Deletes:   m.metrics.deletes.Load(),
+		Conflicts: m.metrics.conflicts.Load(),
+	}
+}
@@ -0,0 +1,22 @@
+package bimap
+
+import "testing"
+
+func TestMetrics(t *testing.T) {
+	m := New(WithMetrics[string, int]())
+	m.SetFront("a", 1)
+	m.SetBack(2, "b")
+	m.SetFront("c", 1)
+	m.GetFront("a")
+	m.GetBack(2)
+	m.GetFront("z")
+	m.DeleteFront("a")
+	m.DeleteFront("z")
+	want := BiMapMetrics{Sets: 2, Gets: 3, Deletes: 1, Conflicts: 1}
+	if got := m.Metrics(); got != want {
+		t.Errorf("Metrics not equal, want: %+v, got: %+v", want, got)
+	}
+	if got := New[string, int]().Metrics(); got != (BiMapMetrics{}) {
+		t.Errorf("Metrics should be zero, got: %+v", got)
+	}
+}
@@ -0,0 +1,50 @@
+package bimap
+
+import "cmp"
+
+// FrontRange returns all pairs in front map whose key is within [lo, hi]
+func FrontRange[T cmp.Ordered, U comparable](m *BiMap[T, U], lo, hi T) map[T]U {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	res := make(map[T]U)
+	for k, v := range m.front {
+		if k >= lo && k <= hi {
+			res[k] = v
+		}
+	}
+	return res
+}
+
+// ForOrdered iterates over the front map in ascending key order
+func ForOrdered[T cmp.Ordered, U comparable](m *BiMap[T, U], fn func(f T, b U)) {
+	m.ForSortedFront(cmp.Less[T], fn)
+}
+
+// MinKey returns the pair with the smallest key in front map, it returns false if the map is empty
+func MinKey[T cmp.Ordered, U comparable](m *BiMap[T, U]) (T, U, bool) {
+	return extremeKey(m, cmp.Less[T])
+}
+
+// MaxKey returns the pair with the largest key in front map, it returns false if the map is empty
+func MaxKey[T cmp.Ordered, U comparable](m *BiMap[T, U]) (T, U, bool) {
+	return extremeKey(m, func(a, b T) bool {
+		return a > b
+	})
+}
+
+// extremeKey returns the pair whose key comes first according to before
+func extremeKey[T cmp.Ordered, U comparable](m *BiMap[T, U], before func(a, b T) bool) (T, U, bool) {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	var (
+		rf    T
+		rb    U
+		found bool
+	)
+	for f, b := range m.front {
+		if !found || before(f, rf) {
+			rf, rb, found = f, b, true
+		}
+	}
+	return rf, rb, found
+}
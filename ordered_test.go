@@ -0,0 +1,50 @@
+package bimap
+
+import "testing"
+
+func TestFrontRange(t *testing.T) {
+	m := New(WithInitialMap(map[int64]string{1: "a", 5: "b", 10: "c", 15: "d"}))
+	res := FrontRange(m, 5, 10)
+	if len(res) != 2 || res[5] != "b" || res[10] != "c" {
+		t.Errorf("Unexpected result: %v", res)
+	}
+	if res := FrontRange(m, 6, 9); len(res) != 0 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 0, len(res))
+	}
+	if res := FrontRange(m, 10, 5); len(res) != 0 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 0, len(res))
+	}
+}
+
+func TestForOrdered(t *testing.T) {
+	var keys []string
+	ForOrdered(New(WithInitialMap(map[string]int{"c": 3, "a": 1, "b": 2})), func(f string, b int) {
+		keys = append(keys, f)
+	})
+	if len(keys) != 3 || keys[0] != "a" || keys[1] != "b" || keys[2] != "c" {
+		t.Errorf("Unexpected order: %v", keys)
+	}
+	var ints []int
+	ForOrdered(New(WithInitialMap(map[int]string{10: "j", -1: "z", 2: "b"})), func(f int, b string) {
+		ints = append(ints, f)
+	})
+	if len(ints) != 3 || ints[0] != -1 || ints[1] != 2 || ints[2] != 10 {
+		t.Errorf("Unexpected order: %v", ints)
+	}
+}
+
+func TestMinMaxKey(t *testing.T) {
+	m := New[int, string]()
+	if _, _, ok := MinKey(m); ok {
+		t.Error("Should be empty")
+	}
+	m.SetFront(7, "g")
+	m.SetFront(-2, "m")
+	m.SetFront(15, "o")
+	if f, b, _ := MinKey(m); f != -2 || b != "m" {
+		t.Errorf("Pairs not equal, want: -2:m, got: %d:%s", f, b)
+	}
+	if f, b, _ := MaxKey(m); f != 15 || b != "o" {
+		t.Errorf("Pairs not equal, want: 15:o, got: %d:%s", f, b)
+	}
+}
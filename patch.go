@@ -0,0 +1,97 @@
+package bimap
+
+// OpKind describes the kind of a patch operation
+type OpKind int
+
+const (
+	// OpSet sets the value of the key in front map, replacing its previous value
+	OpSet OpKind = iota
+	// OpDelete deletes the key in front map
+	OpDelete
+)
+
+// Op is a patch operation on front map, Back is unused for OpDelete
+type Op[T, U comparable] struct {
+	Kind  OpKind
+	Front T
+	Back  U
+}
+
+// Patch returns the operations that transform the BiMap object into other when applied in order.
+// Keys that only exist in the BiMap object are deleted, and so are keys whose value is taken over by another key,
+// then every key of other that is absent or has a different value is set
+func (m *BiMap[T, U]) Patch(other *BiMap[T, U]) []Op[T, U] {
+	unlock := rlockPair(&m.rwLock, &other.rwLock)
+	defer unlock()
+	var ops []Op[T, U]
+	for k, v := range m.front {
+		if _, ok := other.front[k]; !ok {
+			ops = append(ops, Op[T, U]{Kind: OpDelete, Front: k})
+			continue
+		}
+		if owner, taken := other.back[v]; taken && owner != k {
+			ops = append(ops, Op[T, U]{Kind: OpDelete, Front: k})
+		}
+	}
+	for k, v := range other.front {
+		if mv, ok := m.front[k]; !ok || mv != v {
+			ops = append(ops, Op[T, U]{Kind: OpSet, Front: k, Back: v})
+		}
+	}
+	return ops
+}
+
+// ApplyPatch applies the operations in order under the write lock, it will return an error without modification
+// if an OpSet would give a value that belongs to another key, or if the operations would remove a key of a grow-only map
+func (m *BiMap[T, U]) ApplyPatch(ops []Op[T, U]) error {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	if m.frozen {
+		return ErrFrozen
+	}
+	// dry run on copies so that nothing is applied unless every operation succeeds
+	front := make(map[T]U, len(m.front))
+	back := make(map[U]T, len(m.back))
+	for k, v := range m.front {
+		front[k] = v
+		back[v] = k
+	}
+	for _, op := range ops {
+		switch op.Kind {
+		case OpSet:
+			if k, ok := back[op.Back]; ok && k != op.Front {
+				m.metrics.conflict()
+				return ErrKeyValExists
+			}
+			if err := m.validate(op.Front, op.Back); err != nil {
+				return err
+			}
+			if v, ok := front[op.Front]; ok {
+				delete(back, v)
+			}
+			front[op.Front] = op.Back
+			back[op.Back] = op.Front
+		case OpDelete:
+			if v, ok := front[op.Front]; ok {
+				delete(front, op.Front)
+				delete(back, v)
+			}
+		}
+	}
+	if err := m.checkGrowOnly(front); err != nil {
+		return err
+	}
+	for _, op := range ops {
+		v, ok := m.front[op.Front]
+		if op.Kind == OpSet && ok && v == op.Back {
+			continue
+		}
+		if ok {
+			m.remove(op.Front, v)
+		}
+		if op.Kind == OpSet {
+			m.set(op.Front, op.Back)
+		}
+	}
+	return nil
+}
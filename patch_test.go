@@ -0,0 +1,54 @@
+package bimap
+
+import "testing"
+
+func sameContents[T, U comparable](a, b *BiMap[T, U]) bool {
+	return a.EqualFunc(b, func(x, y U) bool {
+		return x == y
+	})
+}
+
+func TestPatch(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "x": 9}))
+	other := New(WithInitialMap(map[string]int{"a": 2, "b": 1, "c": 3, "d": 5, "e": 4}))
+	ops := m.Patch(other)
+	for _, op := range ops {
+		switch op.Kind {
+		case OpSet:
+			if _, conflicted := m.SetFrontOrReport(op.Front, op.Back); conflicted {
+				t.Fatalf("Op should not conflict: %+v", op)
+			}
+		case OpDelete:
+			m.DeleteFront(op.Front)
+		}
+	}
+	if !sameContents(m, other) {
+		t.Errorf("Maps not equal, want: %v, got: %v", other, m)
+	}
+	if ops := m.Patch(other); len(ops) != 0 {
+		t.Errorf("Patch of equal maps should be empty, got: %v", ops)
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2, "c": 3}))
+	other := New(WithInitialMap(map[string]int{"a": 2, "b": 1, "d": 4}))
+	if err := m.ApplyPatch(m.Patch(other)); err != nil {
+		t.Fatal(err)
+	}
+	if !sameContents(m, other) {
+		t.Errorf("Maps not equal, want: %v, got: %v", other, m)
+	}
+	before := m.Front()
+	err := m.ApplyPatch([]Op[string, int]{
+		{Kind: OpDelete, Front: "d"},
+		{Kind: OpSet, Front: "e", Back: 5},
+		{Kind: OpSet, Front: "f", Back: 1},
+	})
+	if err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+	if !sameContents(m, New(WithInitialMap(before))) {
+		t.Errorf("Map should be rolled back, want: %v, got: %v", before, m)
+	}
+}
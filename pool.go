@@ -0,0 +1,33 @@
+package bimap
+
+import "sync"
+
+// Pool is a set of cleared BiMap objects that may be reused, the zero value is ready to use
+type Pool[T, U comparable] struct {
+	pool sync.Pool
+}
+
+// Get returns a cleared BiMap object from the pool, or a new one if the pool is empty
+func (p *Pool[T, U]) Get() *BiMap[T, U] {
+	if m, ok := p.pool.Get().(*BiMap[T, U]); ok {
+		return m
+	}
+	return New[T, U]()
+}
+
+// Put resets the BiMap object to the state of New without options and returns it to the pool, only the allocated
+// maps are kept. No events are sent for the removed pairs and subscriptions stop receiving events, the BiMap object
+// must not be used after Put. Frozen and grow-only BiMap objects cannot be cleared and are dropped instead
+func (p *Pool[T, U]) Put(m *BiMap[T, U]) {
+	m.rwLock.Lock()
+	if m.frozen || m.growOnly {
+		m.rwLock.Unlock()
+		return
+	}
+	front, back := m.front, m.back
+	clear(front)
+	clear(back)
+	m.rwLock.Unlock()
+	*m = BiMap[T, U]{front: front, back: back}
+	p.pool.Put(m)
+}
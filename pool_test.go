@@ -0,0 +1,93 @@
+package bimap
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPool(t *testing.T) {
+	var p Pool[string, int]
+	m := p.Get()
+	m.SetFront("a", 1)
+	p.Put(m)
+	m = p.Get()
+	if l := m.Len(); l != 0 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 0, l)
+	}
+	if err := m.SetFront("a", 1); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPoolDropsUnclearable(t *testing.T) {
+	var p Pool[string, int]
+	grow := New(WithInitialMap(map[string]int{"a": 1}), WithGrowOnly[string, int]())
+	frozen := New(WithInitialMap(map[string]int{"b": 2}))
+	frozen.Freeze()
+	p.Put(grow)
+	p.Put(frozen)
+	if grow.Len() != 1 || frozen.Len() != 1 {
+		t.Error("Unclearable maps should not be modified")
+	}
+	for i := 0; i < 3; i++ {
+		m := p.Get()
+		if m == grow || m == frozen {
+			t.Fatal("Unclearable maps should not be pooled")
+		}
+		if l := m.Len(); l != 0 {
+			t.Errorf("Lengths not equal, want: %d, got: %d", 0, l)
+		}
+	}
+}
+
+func TestPoolResetsOptions(t *testing.T) {
+	var p Pool[string, int]
+	var events int
+	m := New(
+		WithValidator(func(f string, b int) error {
+			if b < 0 {
+				return errors.New("negative value")
+			}
+			return nil
+		}),
+		WithObserver(func(Event[string, int]) { events++ }),
+		WithDefaultValue[string](7, true),
+		WithMetrics[string, int](),
+		WithInsertionOrder[string, int](),
+	)
+	ch, _ := m.Subscribe(8)
+	m.Checkpoint()
+	m.SetFront("a", 1)
+	m.DeleteFront("a")
+	m.SetFront("b", 2)
+	<-ch
+	<-ch
+	<-ch
+	events = 0
+	p.Put(m)
+
+	if events != 0 || len(ch) != 0 {
+		t.Errorf("Put should not send events, got: %d and %d", events, len(ch))
+	}
+	if l := m.Len(); l != 0 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 0, l)
+	}
+	if err := m.SetFront("c", -3); err != nil {
+		t.Errorf("Errors not equal, want: %v, got: %v", nil, err)
+	}
+	if _, ok := m.GetFront("x"); ok {
+		t.Error("Default value should be reset")
+	}
+	if got := m.Metrics(); got != (BiMapMetrics{}) {
+		t.Errorf("Values not equal, want: %v, got: %v", BiMapMetrics{}, got)
+	}
+	if events != 0 || len(ch) != 0 {
+		t.Errorf("Old observers and subscribers should not receive events, got: %d and %d", events, len(ch))
+	}
+	if _, _, ok := m.Oldest(); ok {
+		t.Error("Insertion order should not be tracked")
+	}
+	if m.changes != nil {
+		t.Error("Change tracking should be reset")
+	}
+}
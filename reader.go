@@ -0,0 +1,62 @@
+package bimap
+
+import (
+	"fmt"
+	"io"
+)
+
+// stringReader produces the string representation of a BiMap object pair by pair
+type stringReader[T, U comparable] struct {
+	m    *BiMap[T, U]
+	keys []T
+	buf  []byte
+	n    int
+	done bool
+}
+
+// StringReader returns a reader that produces the same layout as String without building the whole string,
+// the keys are copied under the read lock first and each value is looked up as it is read,
+// so pairs deleted meanwhile are skipped and changed values are reflected
+func (m *BiMap[T, U]) StringReader() io.Reader {
+	m.rwLock.RLock()
+	keys := make([]T, 0, len(m.front))
+	for f := range m.front {
+		keys = append(keys, f)
+	}
+	m.rwLock.RUnlock()
+	return &stringReader[T, U]{m: m, keys: keys, buf: []byte("map[")}
+}
+
+func (r *stringReader[T, U]) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		r.fill()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// fill appends the next pair, or the closing bracket after the last one, to the buffer
+func (r *stringReader[T, U]) fill() {
+	for len(r.keys) > 0 {
+		f := r.keys[0]
+		r.keys = r.keys[1:]
+		r.m.rwLock.RLock()
+		b, ok := r.m.front[f]
+		r.m.rwLock.RUnlock()
+		if !ok {
+			continue
+		}
+		if r.n > 0 {
+			r.buf = append(r.buf, ' ')
+		}
+		r.buf = fmt.Appendf(r.buf, "%v:%v", f, b)
+		r.n++
+		return
+	}
+	r.buf = append(r.buf, ']')
+	r.done = true
+}
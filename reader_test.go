@@ -0,0 +1,32 @@
+package bimap
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestStringReader(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2, "c": 3}))
+	data, err := io.ReadAll(m.StringReader())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := func(s string) []string {
+		f := strings.Fields(strings.TrimSuffix(strings.TrimPrefix(s, "map["), "]"))
+		sort.Strings(f)
+		return f
+	}
+	got, want := fields(string(data)), fields(m.String())
+	if !strings.HasPrefix(string(data), "map[") || !strings.HasSuffix(string(data), "]") {
+		t.Errorf("Unexpected layout: %s", data)
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Strings not equal, want: %v, got: %v", want, got)
+	}
+	data, _ = io.ReadAll(New[string, int]().StringReader())
+	if string(data) != "map[]" {
+		t.Errorf("Strings not equal, want: %s, got: %s", "map[]", data)
+	}
+}
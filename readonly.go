@@ -0,0 +1,42 @@
+package bimap
+
+// ReadOnly is a read-only view of a BiMap object that is only valid inside RLockFunc,
+// its methods read the maps directly since the read lock is already held
+type ReadOnly[T, U comparable] struct {
+	m *BiMap[T, U]
+}
+
+// RLockFunc calls fn with a read-only view while holding the read lock, so all reads in fn are consistent
+// with each other. Writers are blocked until fn returns, and fn must not call methods of the BiMap object
+// or keep the view after returning
+func (m *BiMap[T, U]) RLockFunc(fn func(r *ReadOnly[T, U])) {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	fn(&ReadOnly[T, U]{m: m})
+}
+
+// GetFront returns the value and its existence by the given key in front map,
+// the default value and the loader of the BiMap object are not applied
+func (r *ReadOnly[T, U]) GetFront(key T) (U, bool) {
+	v, ok := r.m.front[key]
+	return v, ok
+}
+
+// GetBack returns the value and its existence by the given key in back map,
+// the back read transform of the BiMap object is not applied
+func (r *ReadOnly[T, U]) GetBack(key U) (T, bool) {
+	v, ok := r.m.back[key]
+	return v, ok
+}
+
+// Len returns the length of the BiMap object
+func (r *ReadOnly[_, _]) Len() int {
+	return len(r.m.front)
+}
+
+// For iterate over the map for the given function
+func (r *ReadOnly[T, U]) For(fn func(f T, b U)) {
+	for f, b := range r.m.front {
+		fn(f, b)
+	}
+}
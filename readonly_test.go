@@ -0,0 +1,43 @@
+package bimap
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRLockFunc(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	var written atomic.Bool
+	done := make(chan struct{})
+	m.RLockFunc(func(r *ReadOnly[string, int]) {
+		go func() {
+			m.SetFront("c", 3)
+			written.Store(true)
+			close(done)
+		}()
+		for i := 0; i < 100; i++ {
+			runtime.Gosched()
+		}
+		if written.Load() {
+			t.Error("Writer should be blocked")
+		}
+		if _, ok := r.GetFront("c"); ok {
+			t.Error("Key should not exist")
+		}
+		if k, ok := r.GetBack(2); !ok || k != "b" {
+			t.Errorf("Values not equal, want: %s, got: %s", "b", k)
+		}
+		sum := 0
+		r.For(func(_ string, b int) {
+			sum += b
+		})
+		if sum != 3 || r.Len() != 2 {
+			t.Errorf("Values not equal, want: %d, got: %d", 3, sum)
+		}
+	})
+	<-done
+	if v, _ := m.GetFront("c"); v != 3 {
+		t.Errorf("Values not equal, want: %d, got: %d", 3, v)
+	}
+}
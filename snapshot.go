@@ -0,0 +1,46 @@
+package bimap
+
+// FrozenBiMap is an immutable point-in-time copy of a BiMap object, it is safe for concurrent use without locking
+type FrozenBiMap[T, U comparable] struct {
+	front map[T]U
+	back  map[U]T
+}
+
+// Snapshot returns a FrozenBiMap object with a copy of all pairs, later changes to the BiMap object do not affect it
+func (m *BiMap[T, U]) Snapshot() *FrozenBiMap[T, U] {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	s := &FrozenBiMap[T, U]{
+		front: make(map[T]U, len(m.front)),
+		back:  make(map[U]T, len(m.back)),
+	}
+	for k, v := range m.front {
+		s.front[k] = v
+		s.back[v] = k
+	}
+	return s
+}
+
+// GetFront returns the value and its existence by the given key in front map
+func (s *FrozenBiMap[T, U]) GetFront(key T) (U, bool) {
+	v, ok := s.front[key]
+	return v, ok
+}
+
+// GetBack returns the value and its existence by the given key in back map
+func (s *FrozenBiMap[T, U]) GetBack(key U) (T, bool) {
+	v, ok := s.back[key]
+	return v, ok
+}
+
+// Len returns the length of the FrozenBiMap object
+func (s *FrozenBiMap[_, _]) Len() int {
+	return len(s.front)
+}
+
+// For iterate over the map for the given function
+func (s *FrozenBiMap[T, U]) For(fn func(f T, b U)) {
+	for f, b := range s.front {
+		fn(f, b)
+	}
+}
@@ -0,0 +1,26 @@
+package bimap
+
+import "testing"
+
+func TestSnapshot(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	s := m.Snapshot()
+	m.SetFront("c", 3)
+	m.DeleteFront("a")
+	if l := s.Len(); l != 2 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 2, l)
+	}
+	if val, ok := s.GetFront("a"); !ok || val != 1 {
+		t.Errorf("Values not equal, want: %d, got: %d", 1, val)
+	}
+	if _, ok := s.GetBack(3); ok {
+		t.Error("Snapshot should not see later changes")
+	}
+	n := 0
+	s.For(func(f string, b int) {
+		n++
+	})
+	if n != 2 {
+		t.Errorf("Counts not equal, want: %d, got: %d", 2, n)
+	}
+}
@@ -0,0 +1,101 @@
+package bimap
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unique"
+)
+
+var ErrKeyTooLong = errors.New("key too long")
+
+// FrontPrefix returns all pairs in front map whose key starts with prefix
+func FrontPrefix[U comparable](m *BiMap[string, U], prefix string) map[string]U {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	res := make(map[string]U)
+	for k, v := range m.front {
+		if strings.HasPrefix(k, prefix) {
+			res[k] = v
+		}
+	}
+	return res
+}
+
+// WithMaxStringLen returns an option for string-keyed BiMap objects that rejects keys longer than n bytes,
+// it is a validator specialised for string keys, see WithValidator
+func WithMaxStringLen[U comparable](n int) option[string, U] {
+	return WithValidator(func(f string, _ U) error {
+		if len(f) > n {
+			return fmt.Errorf("%w: %d bytes exceeds the limit of %d", ErrKeyTooLong, len(f), n)
+		}
+		return nil
+	})
+}
+
+// BackPrefix returns all pairs in back map whose key starts with prefix
+func BackPrefix[T comparable](m *BiMap[T, string], prefix string) map[string]T {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	res := make(map[string]T)
+	for k, v := range m.back {
+		if strings.HasPrefix(k, prefix) {
+			res[k] = v
+		}
+	}
+	return res
+}
+
+type internOption[U comparable] struct{}
+
+func (internOption[U]) apply(m *BiMap[string, U]) {
+	m.intern = func(s string) string {
+		return unique.Make(s).Value()
+	}
+}
+
+// WithStringInterning returns an option for string-keyed BiMap objects that interns the keys when they are set,
+// so equal keys across all interning BiMap objects share the same backing data. It only applies to string keys,
+// values and the pairs given to WithInitialMap are stored as is
+func WithStringInterning[U comparable]() option[string, U] {
+	return internOption[U]{}
+}
+
+// ToEnv returns the pairs as PREFIX_KEY=VALUE strings sorted in ascending order, in the form of os.Environ.
+// Names are uppercased and characters other than letters, digits and underscores are replaced with underscores,
+// an underscore is prepended to names starting with a digit. The prefix is left out if it is empty,
+// and keys that sanitize to the same name produce repeated names
+func ToEnv(m *BiMap[string, string], prefix string) []string {
+	m.rwLock.RLock()
+	defer m.rwLock.RUnlock()
+	res := make([]string, 0, len(m.front))
+	for k, v := range m.front {
+		name := k
+		if prefix != "" {
+			name = prefix + "_" + k
+		}
+		res = append(res, envName(name)+"="+v)
+	}
+	slices.Sort(res)
+	return res
+}
+
+// envName returns name uppercased with characters invalid in environment variable names replaced with underscores
+func envName(name string) string {
+	var sb strings.Builder
+	sb.Grow(len(name) + 1)
+	for i, r := range strings.ToUpper(name) {
+		switch {
+		case r >= 'A' && r <= 'Z', r == '_':
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				sb.WriteByte('_')
+			}
+		default:
+			r = '_'
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,91 @@
+package bimap
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestFrontPrefix(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"apple": 1, "apricot": 2, "banana": 3, "ap": 4}))
+	res := FrontPrefix(m, "apr")
+	if len(res) != 1 || res["apricot"] != 2 {
+		t.Errorf("Unexpected result: %v", res)
+	}
+	if res := FrontPrefix(m, "ap"); len(res) != 3 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 3, len(res))
+	}
+	if res := FrontPrefix(m, "c"); len(res) != 0 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 0, len(res))
+	}
+}
+
+func TestBackPrefix(t *testing.T) {
+	m := New(WithInitialMap(map[int]string{1: "user:alice", 2: "user:bob", 3: "group:admin"}))
+	res := BackPrefix(m, "user:")
+	if len(res) != 2 || res["user:alice"] != 1 || res["user:bob"] != 2 {
+		t.Errorf("Unexpected result: %v", res)
+	}
+	if res := BackPrefix(m, "team:"); len(res) != 0 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 0, len(res))
+	}
+}
+
+func TestWithMaxStringLen(t *testing.T) {
+	m := New(WithMaxStringLen[int](5))
+	if err := m.SetFront("short", 1); err != nil {
+		t.Error(err)
+	}
+	if err := m.SetFront("too long", 2); !errors.Is(err, ErrKeyTooLong) {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyTooLong, err)
+	}
+	if _, ok := m.GetBack(2); ok {
+		t.Error("Oversized key should not be set")
+	}
+}
+
+func TestWithStringInterning(t *testing.T) {
+	key := func(m *BiMap[string, int]) string {
+		for k := range m.Front() {
+			return k
+		}
+		return ""
+	}
+	a := New(WithStringInterning[int]())
+	b := New(WithStringInterning[int]())
+	a.SetFront(strings.Repeat("k", 32), 1)
+	b.SetFront(strings.Repeat("k", 32), 2)
+	if unsafe.StringData(key(a)) != unsafe.StringData(key(b)) {
+		t.Error("Interned keys should share data")
+	}
+	c := New[string, int]()
+	d := New[string, int]()
+	c.SetFront(strings.Repeat("k", 32), 1)
+	d.SetFront(strings.Repeat("k", 32), 2)
+	if unsafe.StringData(key(c)) == unsafe.StringData(key(d)) {
+		t.Error("Keys should not be interned without the option")
+	}
+	if val, _ := b.GetBack(2); val != strings.Repeat("k", 32) {
+		t.Errorf("Values not equal, want: %s, got: %s", strings.Repeat("k", 32), val)
+	}
+}
+
+func TestToEnv(t *testing.T) {
+	m := New(WithInitialMap(map[string]string{
+		"db-host":   "localhost",
+		"log level": "debug",
+		"port":      "8080",
+		"näme.x":    "a=b",
+	}))
+	want := []string{"APP_DB_HOST=localhost", "APP_LOG_LEVEL=debug", "APP_N_ME_X=a=b", "APP_PORT=8080"}
+	if env := ToEnv(m, "app"); !reflect.DeepEqual(env, want) {
+		t.Errorf("Values not equal, want: %v, got: %v", want, env)
+	}
+	m = New(WithInitialMap(map[string]string{"1st": "x", "b": "y"}))
+	want = []string{"B=y", "_1ST=x"}
+	if env := ToEnv(m, ""); !reflect.DeepEqual(env, want) {
+		t.Errorf("Values not equal, want: %v, got: %v", want, env)
+	}
+}
@@ -0,0 +1,147 @@
+package bimap
+
+import (
+	"hash/maphash"
+	"sync"
+)
+
+// StripedBiMap is a variant of BiMap that spreads its pairs over lock stripes so operations on unrelated keys
+// can proceed concurrently. A pair is stored in the front stripe of its key and the back stripe of its value,
+// operations that need both always lock the front stripe before the back stripe to avoid deadlocks
+type StripedBiMap[T, U comparable] struct {
+	seed  maphash.Seed
+	front []frontStripe[T, U]
+	back  []backStripe[T, U]
+}
+
+type frontStripe[T, U comparable] struct {
+	rwLock sync.RWMutex
+	m      map[T]U
+}
+
+type backStripe[T, U comparable] struct {
+	rwLock sync.RWMutex
+	m      map[U]T
+}
+
+// NewStriped returns a StripedBiMap object with the given number of stripes on each side
+func NewStriped[T, U comparable](stripes int) *StripedBiMap[T, U] {
+	if stripes < 1 {
+		stripes = 1
+	}
+	m := &StripedBiMap[T, U]{
+		seed:  maphash.MakeSeed(),
+		front: make([]frontStripe[T, U], stripes),
+		back:  make([]backStripe[T, U], stripes),
+	}
+	for i := range m.front {
+		m.front[i].m = make(map[T]U)
+		m.back[i].m = make(map[U]T)
+	}
+	return m
+}
+
+func (m *StripedBiMap[T, U]) frontStripe(key T) *frontStripe[T, U] {
+	return &m.front[maphash.Comparable(m.seed, key)%uint64(len(m.front))]
+}
+
+func (m *StripedBiMap[T, U]) backStripe(key U) *backStripe[T, U] {
+	return &m.back[maphash.Comparable(m.seed, key)%uint64(len(m.back))]
+}
+
+// GetFront returns the value and its existence by the given key in front map
+func (m *StripedBiMap[T, U]) GetFront(key T) (U, bool) {
+	fs := m.frontStripe(key)
+	fs.rwLock.RLock()
+	defer fs.rwLock.RUnlock()
+	v, ok := fs.m[key]
+	return v, ok
+}
+
+// GetBack returns the value and its existence by the given key in back map
+func (m *StripedBiMap[T, U]) GetBack(key U) (T, bool) {
+	bs := m.backStripe(key)
+	bs.rwLock.RLock()
+	defer bs.rwLock.RUnlock()
+	v, ok := bs.m[key]
+	return v, ok
+}
+
+// SetFront sets the value with corresponding key in the front map, it will return an error if either key or value exist
+func (m *StripedBiMap[T, U]) SetFront(key T, val U) error {
+	fs, bs := m.frontStripe(key), m.backStripe(val)
+	fs.rwLock.Lock()
+	defer fs.rwLock.Unlock()
+	bs.rwLock.Lock()
+	defer bs.rwLock.Unlock()
+	if _, ok := fs.m[key]; ok {
+		return ErrKeyValExists
+	}
+	if _, ok := bs.m[val]; ok {
+		return ErrKeyValExists
+	}
+	fs.m[key] = val
+	bs.m[val] = key
+	return nil
+}
+
+// SetBack sets the value with corresponding key in the back map, it will return an error if either key or value exist
+func (m *StripedBiMap[T, U]) SetBack(key U, val T) error {
+	return m.SetFront(val, key)
+}
+
+// DeleteFront deletes the value of the given key in front map
+func (m *StripedBiMap[T, U]) DeleteFront(key T) {
+	fs := m.frontStripe(key)
+	fs.rwLock.Lock()
+	defer fs.rwLock.Unlock()
+	v, ok := fs.m[key]
+	if !ok {
+		return
+	}
+	bs := m.backStripe(v)
+	bs.rwLock.Lock()
+	defer bs.rwLock.Unlock()
+	delete(fs.m, key)
+	delete(bs.m, v)
+}
+
+// DeleteBack deletes the value of the given key in back map
+func (m *StripedBiMap[T, U]) DeleteBack(key U) {
+	bs := m.backStripe(key)
+	for {
+		// look up the front key first, then lock both stripes in canonical order and retry if the pair changed meanwhile
+		bs.rwLock.RLock()
+		v, ok := bs.m[key]
+		bs.rwLock.RUnlock()
+		if !ok {
+			return
+		}
+		fs := m.frontStripe(v)
+		fs.rwLock.Lock()
+		bs.rwLock.Lock()
+		cur, ok := bs.m[key]
+		if ok && cur == v {
+			delete(fs.m, v)
+			delete(bs.m, key)
+		}
+		bs.rwLock.Unlock()
+		fs.rwLock.Unlock()
+		if !ok || cur == v {
+			return
+		}
+	}
+}
+
+// Len returns the length of the StripedBiMap object, the stripes are counted one by one
+// so the result may be inconsistent under concurrent writes
+func (m *StripedBiMap[_, _]) Len() int {
+	n := 0
+	for i := range m.front {
+		fs := &m.front[i]
+		fs.rwLock.RLock()
+		n += len(fs.m)
+		fs.rwLock.RUnlock()
+	}
+	return n
+}
@@ -0,0 +1,104 @@
+package bimap
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestStriped(t *testing.T) {
+	m := NewStriped[string, int](8)
+	if err := m.SetFront("a", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetBack(2, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetFront("c", 1); err != ErrKeyValExists {
+		t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+	}
+	if val, _ := m.GetBack(2); val != "b" {
+		t.Errorf("Values not equal, want: %s, got: %s", "b", val)
+	}
+	m.DeleteBack(1)
+	if _, ok := m.GetFront("a"); ok {
+		t.Error("Should be deleted")
+	}
+	m.DeleteFront("b")
+	if _, ok := m.GetBack(2); ok {
+		t.Error("Should be deleted")
+	}
+	if l := m.Len(); l != 0 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 0, l)
+	}
+}
+
+func TestStripedConcurrent(t *testing.T) {
+	m := NewStriped[int, int](4)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				k := (g*1000 + i) % 500
+				switch i % 3 {
+				case 0:
+					m.SetFront(k, k+1000)
+				case 1:
+					m.DeleteBack(k + 1000)
+				default:
+					m.DeleteFront(k)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	for k := 0; k < 500; k++ {
+		v, ok := m.GetFront(k)
+		if !ok {
+			continue
+		}
+		if f, _ := m.GetBack(v); f != k {
+			t.Errorf("Inconsistent pair %d:%d", k, v)
+		}
+	}
+}
+
+func benchmarkMixed(b *testing.B, get func(string) bool, set func(string, int), del func(string)) {
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			k := strconv.Itoa(i % 4096)
+			switch i % 10 {
+			case 0:
+				set(k, i%4096)
+			case 1:
+				del(k)
+			default:
+				get(k)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkMixedBiMap(b *testing.B) {
+	m := New[string, int]()
+	benchmarkMixed(b, func(k string) bool {
+		_, ok := m.GetFront(k)
+		return ok
+	}, func(k string, v int) {
+		m.SetFront(k, v)
+	}, m.DeleteFront)
+}
+
+func BenchmarkMixedStriped(b *testing.B) {
+	m := NewStriped[string, int](64)
+	benchmarkMixed(b, func(k string) bool {
+		_, ok := m.GetFront(k)
+		return ok
+	}, func(k string, v int) {
+		m.SetFront(k, v)
+	}, m.DeleteFront)
+}
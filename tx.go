@@ -0,0 +1,115 @@
+package bimap
+
+// Tx is a transaction on a locked BiMap object, it is only valid inside the function passed to Batch
+type Tx[T, U comparable] struct {
+	m       *BiMap[T, U]
+	journal []txEntry[T, U]
+}
+
+// txEntry records a mutation so that it can be committed or undone
+type txEntry[T, U comparable] struct {
+	f   T
+	b   U
+	set bool
+}
+
+// Batch runs ops under the write lock, if ops returns an error or panics all changes made through tx are rolled back.
+// Changes only reach subscribers, observers, metrics, change tracking and insertion order once ops returns nil,
+// so a rolled back batch leaves no trace besides the conflicts it counted
+func (m *BiMap[T, U]) Batch(ops func(tx *Tx[T, U]) error) error {
+	m.rwLock.Lock()
+	defer m.rwLock.Unlock()
+	if m.frozen {
+		return ErrFrozen
+	}
+	tx := &Tx[T, U]{m: m}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.rollback()
+			panic(r)
+		}
+	}()
+	if err := ops(tx); err != nil {
+		tx.rollback()
+		return err
+	}
+	tx.commit()
+	return nil
+}
+
+// commit applies the side effects of the journaled mutations in order
+func (tx *Tx[T, U]) commit() {
+	for _, e := range tx.journal {
+		if e.set {
+			tx.m.onSet(e.f, e.b)
+		} else {
+			tx.m.onRemove(e.f, e.b)
+		}
+	}
+	tx.journal = nil
+}
+
+// rollback undoes the journaled mutations of both maps in reverse order
+func (tx *Tx[T, U]) rollback() {
+	for i := len(tx.journal) - 1; i >= 0; i-- {
+		e := tx.journal[i]
+		if e.set {
+			tx.m.unstore(e.f, e.b)
+		} else {
+			tx.m.store(e.f, e.b)
+		}
+	}
+	tx.journal = nil
+}
+
+// GetFront returns the value and its existence by the given key in front map
+func (tx *Tx[T, U]) GetFront(key T) (U, bool) {
+	v, ok := tx.m.front[key]
+	return v, ok
+}
+
+// GetBack returns the value and its existence by the given key in back map
+func (tx *Tx[T, U]) GetBack(key U) (T, bool) {
+	v, ok := tx.m.back[key]
+	return v, ok
+}
+
+// SetFront sets the value with corresponding key in the front map, it will return an error if either key or value exist
+func (tx *Tx[T, U]) SetFront(key T, val U) error {
+	if err := tx.m.checkFront(key, val); err != nil {
+		return err
+	}
+	key = tx.m.store(key, val)
+	tx.journal = append(tx.journal, txEntry[T, U]{f: key, b: val, set: true})
+	return nil
+}
+
+// SetBack sets the value with corresponding key in the back map, it will return an error if either key or value exist
+func (tx *Tx[T, U]) SetBack(key U, val T) error {
+	return tx.SetFront(val, key)
+}
+
+// DeleteFront deletes the value of the given key in front map, it does nothing on a grow-only map
+func (tx *Tx[T, U]) DeleteFront(key T) {
+	v, ok := tx.m.front[key]
+	if !ok || tx.m.growOnly {
+		return
+	}
+	tx.m.unstore(key, v)
+	tx.journal = append(tx.journal, txEntry[T, U]{f: key, b: v})
+}
+
+// DeleteBack deletes the value of the given key in back map, it does nothing on a grow-only map
+func (tx *Tx[T, U]) DeleteBack(key U) {
+	v, ok := tx.m.back[key]
+	if !ok || tx.m.growOnly {
+		return
+	}
+	tx.m.unstore(v, key)
+	tx.journal = append(tx.journal, txEntry[T, U]{f: v, b: key})
+}
+
+// Len returns the length of the BiMap object
+func (tx *Tx[_, _]) Len() int {
+	return len(tx.m.front)
+}
@@ -0,0 +1,146 @@
+package bimap
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}))
+	err := m.Batch(func(tx *Tx[string, int]) error {
+		tx.DeleteFront("a")
+		if err := tx.SetFront("c", 1); err != nil {
+			return err
+		}
+		return tx.SetBack(3, "d")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val, _ := m.GetBack(1); val != "c" {
+		t.Errorf("Values not equal, want: %s, got: %s", "c", val)
+	}
+
+	errAbort := errors.New("abort")
+	before := m.Front()
+	err = m.Batch(func(tx *Tx[string, int]) error {
+		tx.DeleteFront("c")
+		tx.DeleteBack(2)
+		if err := tx.SetFront("a", 1); err != nil {
+			return err
+		}
+		if err := tx.SetFront("e", 3); err != ErrKeyValExists {
+			t.Errorf("Errors not equal, want: %v, got: %v", ErrKeyValExists, err)
+		}
+		if l := tx.Len(); l != 2 {
+			t.Errorf("Lengths not equal, want: %d, got: %d", 2, l)
+		}
+		return errAbort
+	})
+	if err != errAbort {
+		t.Errorf("Errors not equal, want: %v, got: %v", errAbort, err)
+	}
+	after := m.Front()
+	if len(after) != len(before) {
+		t.Fatalf("Lengths not equal, want: %d, got: %d", len(before), len(after))
+	}
+	for k, v := range before {
+		if after[k] != v {
+			t.Errorf("Values not equal, want: %d, got: %d", v, after[k])
+		}
+		if key, _ := m.GetBack(v); key != k {
+			t.Errorf("Values not equal, want: %s, got: %s", k, key)
+		}
+	}
+}
+
+func TestBatchRollbackSideEffects(t *testing.T) {
+	var observed []Event[string, int]
+	m := New(WithObserver(func(e Event[string, int]) {
+		observed = append(observed, e)
+	}))
+	m.SetFront("a", 1)
+	m.SetFront("b", 2)
+	ch, unsubscribe := m.Subscribe(16)
+	defer unsubscribe()
+	observed = nil
+
+	errAbort := errors.New("abort")
+	err := m.Batch(func(tx *Tx[string, int]) error {
+		tx.DeleteFront("a")
+		tx.DeleteFront("b")
+		if err := tx.SetFront("c", 3); err != nil {
+			return err
+		}
+		tx.DeleteFront("c")
+		return tx.SetFront("b", 2)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f, b, _ := m.LastModified(); f != "b" || b != 2 {
+		t.Errorf("Values not equal, want: %v, got: %v", "b 2", []any{f, b})
+	}
+	want := []Event[string, int]{
+		{EventDelete, "a", 1}, {EventDelete, "b", 2}, {EventSet, "c", 3}, {EventDelete, "c", 3}, {EventSet, "b", 2},
+	}
+	if len(observed) != len(want) || len(ch) != len(want) {
+		t.Fatalf("Lengths not equal, want: %d, got: %d and %d", len(want), len(observed), len(ch))
+	}
+	for i, e := range want {
+		if got := <-ch; observed[i] != e || got != e {
+			t.Errorf("Values not equal, want: %v, got: %v and %v", e, observed[i], got)
+		}
+	}
+
+	m.SetFront("a", 1)
+	observed = nil
+	err = m.Batch(func(tx *Tx[string, int]) error {
+		tx.DeleteFront("a")
+		tx.SetFront("d", 4)
+		return errAbort
+	})
+	if err != errAbort {
+		t.Errorf("Errors not equal, want: %v, got: %v", errAbort, err)
+	}
+	<-ch
+	if len(observed) != 0 || len(ch) != 0 {
+		t.Errorf("Lengths not equal, want: %d, got: %d and %d", 0, len(observed), len(ch))
+	}
+	if f, b, ok := m.LastModified(); !ok || f != "a" || b != 1 {
+		t.Errorf("Values not equal, want: %v, got: %v", "a 1 true", []any{f, b, ok})
+	}
+}
+
+func TestBatchRollbackPanic(t *testing.T) {
+	var observed []Event[string, int]
+	m := New(WithInitialMap(map[string]int{"a": 1, "b": 2}), WithObserver(func(e Event[string, int]) {
+		observed = append(observed, e)
+	}))
+	errAbort := errors.New("abort")
+	func() {
+		defer func() {
+			if r := recover(); r != errAbort {
+				t.Errorf("Values not equal, want: %v, got: %v", errAbort, r)
+			}
+		}()
+		m.Batch(func(tx *Tx[string, int]) error {
+			tx.DeleteFront("a")
+			tx.SetFront("c", 3)
+			panic(errAbort)
+		})
+		t.Error("Batch should panic")
+	}()
+	if !sameContents(m, New(WithInitialMap(map[string]int{"a": 1, "b": 2}))) {
+		t.Errorf("Unexpected contents: %v", m.Front())
+	}
+	if err := m.CheckInvariant(); err != nil {
+		t.Errorf("Errors not equal, want: %v, got: %v", nil, err)
+	}
+	if len(observed) != 0 {
+		t.Errorf("Lengths not equal, want: %d, got: %d", 0, len(observed))
+	}
+	if err := m.SetFront("d", 4); err != nil {
+		t.Errorf("Errors not equal, want: %v, got: %v", nil, err)
+	}
+}